@@ -0,0 +1,251 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// GraphAnalysis is the result of statically analysing a graph's topology
+type GraphAnalysis struct {
+	Reachable      []string   // Nodes reachable from __start__
+	Unreachable    []string   // Nodes that can never run because no path leads to them from __start__
+	CannotReachEnd []string   // Nodes from which no path leads to __end__
+	Cycles         [][]string // Groups of nodes that form a cycle, such as an agent/tools loop
+}
+
+// AnalyzeGraph computes reachability and cycles for a graph as returned by
+// AssistantsClient.GetGraph. Node lists follow the order of graph.Nodes.
+func AnalyzeGraph(graph schema.Graph) GraphAnalysis {
+	nodes := graphNodeIDs(graph)
+	forward := map[string][]string{}
+	backward := map[string][]string{}
+	for _, edge := range graph.Edges {
+		forward[edge.Source] = append(forward[edge.Source], edge.Target)
+		backward[edge.Target] = append(backward[edge.Target], edge.Source)
+	}
+
+	fromStart := walkGraph(StartNode, forward)
+	toEnd := walkGraph(EndNode, backward)
+
+	analysis := GraphAnalysis{
+		Reachable:      []string{},
+		Unreachable:    []string{},
+		CannotReachEnd: []string{},
+		Cycles:         findCycles(nodes, forward),
+	}
+	for _, id := range nodes {
+		if fromStart[id] {
+			analysis.Reachable = append(analysis.Reachable, id)
+		} else if id != StartNode {
+			analysis.Unreachable = append(analysis.Unreachable, id)
+		}
+		if !toEnd[id] && id != EndNode {
+			analysis.CannotReachEnd = append(analysis.CannotReachEnd, id)
+		}
+	}
+
+	return analysis
+}
+
+// ValidateInterruptNodes checks that every node named in interruptBefore and
+// interruptAfter exists in the graph. The "*" wildcard is always accepted.
+func ValidateInterruptNodes(graph schema.Graph, interruptBefore *[]string, interruptAfter *[]string) error {
+	known := map[string]bool{}
+	for _, id := range graphNodeIDs(graph) {
+		known[id] = true
+	}
+
+	check := func(field string, names *[]string) error {
+		if names == nil {
+			return nil
+		}
+		unknown := []string{}
+		for _, name := range *names {
+			if name != string(schema.AllWildcard) && !known[name] {
+				unknown = append(unknown, name)
+			}
+		}
+		if len(unknown) == 0 {
+			return nil
+		}
+		available := []string{}
+		for _, id := range graphNodeIDs(graph) {
+			if id != StartNode && id != EndNode {
+				available = append(available, id)
+			}
+		}
+		return fmt.Errorf("%s references unknown node(s) %s; the graph has nodes %s",
+			field, strings.Join(unknown, ", "), strings.Join(available, ", "))
+	}
+
+	if err := check("interrupt_before", interruptBefore); err != nil {
+		return err
+	}
+	return check("interrupt_after", interruptAfter)
+}
+
+// RunValidator checks a run before RunsClient sends it to the server
+type RunValidator interface {
+	ValidateRun(ctx context.Context, run schema.RunCreate, headers *map[string]string) error
+}
+
+// GraphValidator is a RunValidator that rejects runs whose interrupt nodes do
+// not exist in the assistant's graph. Graphs are fetched once per assistant,
+// and dropped when the assistant's graph is changed through the same
+// AssistantsClient.
+type GraphValidator struct {
+	assistants *AssistantsClient
+	mu         sync.Mutex
+	graphs     map[string]schema.Graph
+}
+
+func NewGraphValidator(assistants *AssistantsClient) *GraphValidator {
+	v := &GraphValidator{assistants: assistants, graphs: map[string]schema.Graph{}}
+	assistants.invalidators = append(assistants.invalidators, v)
+	return v
+}
+
+func (v *GraphValidator) ValidateRun(ctx context.Context, run schema.RunCreate, headers *map[string]string) error {
+	if len(run.InterruptBefore) == 0 && len(run.InterruptAfter) == 0 {
+		return nil
+	}
+
+	graph, err := v.graph(ctx, run.AssistantID, headers)
+	if err != nil {
+		return err
+	}
+
+	return ValidateInterruptNodes(graph, &run.InterruptBefore, &run.InterruptAfter)
+}
+
+// InvalidateAssistant drops the cached graph of an assistant, e.g. after it was
+// updated through another client.
+func (v *GraphValidator) InvalidateAssistant(assistantID string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.graphs, assistantID)
+}
+
+func (v *GraphValidator) graph(ctx context.Context, assistantID string, headers *map[string]string) (schema.Graph, error) {
+	v.mu.Lock()
+	graph, ok := v.graphs[assistantID]
+	v.mu.Unlock()
+	if ok {
+		return graph, nil
+	}
+
+	graph, err := v.assistants.GetGraph(ctx, assistantID, nil, headers)
+	if err != nil {
+		return schema.Graph{}, err
+	}
+
+	v.mu.Lock()
+	v.graphs[assistantID] = graph
+	v.mu.Unlock()
+
+	return graph, nil
+}
+
+func graphNodeIDs(graph schema.Graph) []string {
+	seen := map[string]bool{}
+	ids := []string{}
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, node := range graph.Nodes {
+		add(node.ID)
+	}
+	for _, edge := range graph.Edges {
+		add(edge.Source)
+		add(edge.Target)
+	}
+	return ids
+}
+
+func walkGraph(from string, adjacency map[string][]string) map[string]bool {
+	visited := map[string]bool{}
+	stack := []string{from}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		stack = append(stack, adjacency[id]...)
+	}
+	return visited
+}
+
+// findCycles returns the strongly connected components that contain a cycle,
+// using Tarjan's algorithm.
+func findCycles(nodes []string, adjacency map[string][]string) [][]string {
+	order := make(map[string]int, len(nodes))
+	for i, id := range nodes {
+		order[id] = i
+	}
+
+	index := 0
+	indexes := map[string]int{}
+	lowlinks := map[string]int{}
+	onStack := map[string]bool{}
+	stack := []string{}
+	cycles := [][]string{}
+
+	var connect func(id string)
+	connect = func(id string) {
+		indexes[id] = index
+		lowlinks[id] = index
+		index++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		selfLoop := false
+		for _, next := range adjacency[id] {
+			if next == id {
+				selfLoop = true
+			}
+			if _, visited := indexes[next]; !visited {
+				connect(next)
+				lowlinks[id] = min(lowlinks[id], lowlinks[next])
+			} else if onStack[next] {
+				lowlinks[id] = min(lowlinks[id], indexes[next])
+			}
+		}
+
+		if lowlinks[id] != indexes[id] {
+			return
+		}
+		component := []string{}
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 || selfLoop {
+			sort.Slice(component, func(i, j int) bool { return order[component[i]] < order[component[j]] })
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, id := range nodes {
+		if _, visited := indexes[id]; !visited {
+			connect(id)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return order[cycles[i][0]] < order[cycles[j][0]] })
+	return cycles
+}
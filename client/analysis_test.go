@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/http"
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeGraph(t *testing.T) {
+	graph := testGraph()
+	graph.Nodes = append(graph.Nodes, schema.Node{ID: "orphan"}, schema.Node{ID: "sink"})
+	graph.Edges = append(graph.Edges, schema.Edge{Source: "orphan", Target: "sink"})
+
+	analysis := AnalyzeGraph(graph)
+
+	assert.Equal(t, []string{"__start__", "agent", "tools:search", "tools:fetch", "__end__"}, analysis.Reachable)
+	assert.Equal(t, []string{"orphan", "sink"}, analysis.Unreachable)
+	assert.Equal(t, []string{"orphan", "sink"}, analysis.CannotReachEnd)
+	assert.Equal(t, [][]string{{"agent", "tools:search", "tools:fetch"}}, analysis.Cycles)
+}
+
+func TestValidateInterruptNodes(t *testing.T) {
+	graph := testGraph()
+
+	assert.NoError(t, ValidateInterruptNodes(graph, &[]string{"agent"}, &[]string{"*"}))
+	assert.EqualError(t,
+		ValidateInterruptNodes(graph, nil, &[]string{"agent", "tool"}),
+		"interrupt_after references unknown node(s) tool; the graph has nodes agent, tools:search, tools:fetch")
+}
+
+func TestGraphValidator(t *testing.T) {
+	fetches := 0
+	assistants := NewAssistantsClient(http.NewHttpClient("http://assistants.test", nil, 5*time.Second, roundTripFunc(func(req *nethttp.Request) (int, string) {
+		fetches++
+		data, _ := json.Marshal(testGraph())
+		return 200, string(data)
+	})))
+	validator := NewGraphValidator(assistants)
+	ctx := context.Background()
+
+	assert.NoError(t, validator.ValidateRun(ctx, schema.RunCreate{AssistantID: "a1"}, nil))
+	assert.Equal(t, 0, fetches)
+
+	assert.NoError(t, validator.ValidateRun(ctx, schema.RunCreate{AssistantID: "a1", InterruptBefore: []string{"agent"}}, nil))
+	assert.ErrorContains(t, validator.ValidateRun(ctx, schema.RunCreate{AssistantID: "a1", InterruptAfter: []string{"tool"}}, nil), "unknown node(s) tool")
+	assert.Equal(t, 1, fetches)
+
+	validator.InvalidateAssistant("a1")
+	assert.NoError(t, validator.ValidateRun(ctx, schema.RunCreate{AssistantID: "a1", InterruptBefore: []string{"agent"}}, nil))
+	assert.Equal(t, 2, fetches)
+}
+
+func TestGraphValidatorInvalidatedOnGraphChange(t *testing.T) {
+	fetches := 0
+	assistants := NewAssistantsClient(http.NewHttpClient("http://assistants.test", nil, 5*time.Second, roundTripFunc(func(req *nethttp.Request) (int, string) {
+		if req.Method == nethttp.MethodPatch {
+			return 200, `{"assistant_id":"a1","graph_id":"other"}`
+		}
+		fetches++
+		data, _ := json.Marshal(testGraph())
+		return 200, string(data)
+	})))
+	validator := NewGraphValidator(assistants)
+	ctx := context.Background()
+	run := schema.RunCreate{AssistantID: "a1", InterruptBefore: []string{"agent"}}
+
+	assert.NoError(t, validator.ValidateRun(ctx, run, nil))
+	assert.Equal(t, 1, fetches)
+
+	// Updates that keep the graph leave the cache alone.
+	name := "renamed"
+	_, err := assistants.Update(ctx, "a1", nil, nil, nil, &name, nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, validator.ValidateRun(ctx, run, nil))
+	assert.Equal(t, 1, fetches)
+
+	graphID := "other"
+	_, err = assistants.Update(ctx, "a1", &graphID, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, validator.ValidateRun(ctx, run, nil))
+	assert.Equal(t, 2, fetches)
+}
@@ -13,13 +13,52 @@ import (
 )
 
 type AssistantsClient struct {
-	http *http.HttpClient
+	http         *http.HttpClient
+	validators   []AssistantValidator
+	invalidators []assistantInvalidator // Caches outside validators, e.g. GraphValidator
 }
 
 func NewAssistantsClient(httpClient *http.HttpClient) *AssistantsClient {
 	return &AssistantsClient{http: httpClient}
 }
 
+// AddValidator registers a validator that checks the config passed to Create
+// and Update before the request is sent.
+func (c *AssistantsClient) AddValidator(validator AssistantValidator) {
+	c.validators = append(c.validators, validator)
+}
+
+func (c *AssistantsClient) validateAssistant(ctx context.Context, assistantID *string, graphID *string, config *schema.Config, headers *map[string]string) error {
+	var id, graph string
+	if assistantID != nil {
+		id = *assistantID
+	}
+	if graphID != nil {
+		graph = *graphID
+	}
+
+	for _, validator := range c.validators {
+		if err := validator.ValidateAssistant(ctx, id, graph, config, headers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// invalidateValidators drops what validators cached for an assistant whose
+// graph changed.
+func (c *AssistantsClient) invalidateValidators(assistantID string) {
+	for _, validator := range c.validators {
+		if v, ok := validator.(assistantInvalidator); ok {
+			v.InvalidateAssistant(assistantID)
+		}
+	}
+	for _, invalidator := range c.invalidators {
+		invalidator.InvalidateAssistant(assistantID)
+	}
+}
+
 func (c *AssistantsClient) Get(ctx context.Context, assistantID string, headers *map[string]string) (schema.Assistant, error) {
 	resp, err := c.http.Get(ctx, fmt.Sprintf("/assistants/%s", assistantID), nil, headers)
 	if err != nil {
@@ -76,7 +115,9 @@ func (c *AssistantsClient) GetSubgraphs(ctx context.Context, assistantID string,
 	)
 
 	params := url.Values{}
-	params.Set("recurse", fmt.Sprintf("%v", *recurse))
+	if recurse != nil {
+		params.Set("recurse", fmt.Sprintf("%v", *recurse))
+	}
 
 	if namespace != nil {
 		resp, err = c.http.Get(ctx, fmt.Sprintf("/assistants/%s/subgraphs/%s", assistantID, *namespace), params, headers)
@@ -100,6 +141,10 @@ func (c *AssistantsClient) GetSubgraphs(ctx context.Context, assistantID string,
 }
 
 func (c *AssistantsClient) Create(ctx context.Context, graphID *string, config *schema.Config, metadata *schema.Json, assistantID *string, ifExists *schema.OnConflictBehavior, name *string, headers *map[string]string, description *string) (schema.Assistant, error) {
+	if err := c.validateAssistant(ctx, assistantID, graphID, config, headers); err != nil {
+		return schema.Assistant{}, err
+	}
+
 	payload := map[string]any{
 		"graph_id": graphID,
 	}
@@ -142,6 +187,10 @@ func (c *AssistantsClient) Create(ctx context.Context, graphID *string, config *
 }
 
 func (c *AssistantsClient) Update(ctx context.Context, assistantID string, graphID *string, config *schema.Config, metadata *schema.Json, name *string, headers *map[string]string, description *string) (schema.Assistant, error) {
+	if err := c.validateAssistant(ctx, &assistantID, graphID, config, headers); err != nil {
+		return schema.Assistant{}, err
+	}
+
 	payload := map[string]any{}
 	if graphID != nil {
 		payload["graph_id"] = *graphID
@@ -164,6 +213,11 @@ func (c *AssistantsClient) Update(ctx context.Context, assistantID string, graph
 		fmt.Println("Error: cleanedPayload is not a map[string]any")
 	}
 
+	if graphID != nil {
+		// Invalidate even on failure, as the update may have been applied.
+		defer c.invalidateValidators(assistantID)
+	}
+
 	resp, err := c.http.Patch(ctx, fmt.Sprintf("/assistants/%s", assistantID), payload, headers)
 	if err != nil {
 		return schema.Assistant{}, err
@@ -233,7 +287,7 @@ func (c *AssistantsClient) Search(ctx context.Context, metadata *schema.Json, gr
 	return assistants, nil
 }
 
-func (c *AssistantsClient) GetVersions(ctx context.Context, assistantID string, metadata *schema.Json, limit *int, offset *int, headers *map[string]string) ([]schema.Assistant, error) {
+func (c *AssistantsClient) GetVersions(ctx context.Context, assistantID string, metadata *schema.Json, limit *int, offset *int, headers *map[string]string) ([]schema.AssistantVersion, error) {
 	if limit != nil && *limit <= 0 {
 		*limit = 10
 	}
@@ -257,17 +311,17 @@ func (c *AssistantsClient) GetVersions(ctx context.Context, assistantID string,
 
 	resp, err := c.http.Post(ctx, fmt.Sprintf("/assistants/%s/versions", assistantID), payload, headers)
 	if err != nil {
-		return []schema.Assistant{}, err
+		return []schema.AssistantVersion{}, err
 	}
 
-	var assistants []schema.Assistant
+	var versions []schema.AssistantVersion
 
-	err = json.Unmarshal(resp.Body(), &assistants)
+	err = json.Unmarshal(resp.Body(), &versions)
 	if err != nil {
-		return []schema.Assistant{}, err
+		return []schema.AssistantVersion{}, err
 	}
 
-	return assistants, nil
+	return versions, nil
 }
 
 func (c *AssistantsClient) SetLatest(ctx context.Context, assistantID string, version *int, headers *map[string]string) (schema.Assistant, error) {
@@ -0,0 +1,386 @@
+package client
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// CacheOptions bounds a read-through cache
+type CacheOptions struct {
+	TTL        time.Duration // How long an entry is served; zero keeps entries until evicted
+	MaxEntries int           // Maximum number of entries, evicting the least recently used; defaults to 1000
+}
+
+// CacheStats reports the effectiveness of a cache
+type CacheStats struct {
+	Hits      int64 // Lookups served from the cache
+	Misses    int64 // Lookups that went to the server
+	Evictions int64 // Entries dropped to stay within MaxEntries
+	Entries   int   // Entries currently cached, including expired ones not yet dropped
+}
+
+// lruCache is a size bounded LRU cache whose entries expire after a TTL
+type lruCache struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu         sync.Mutex
+	order      *list.List // Front is most recently used
+	entries    map[string]*list.Element
+	stats      CacheStats
+	generation uint64 // Incremented by every invalidation
+}
+
+type lruEntry struct {
+	key       string
+	value     any
+	expiresAt time.Time
+}
+
+func newLRUCache(opts CacheOptions) *lruCache {
+	if opts.MaxEntries <= 0 {
+		opts.MaxEntries = 1000
+	}
+	return &lruCache{
+		ttl:        opts.TTL,
+		maxEntries: opts.MaxEntries,
+		now:        time.Now,
+		order:      list.New(),
+		entries:    map[string]*list.Element{},
+	}
+}
+
+func (c *lruCache) get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if ok {
+		entry := element.Value.(*lruEntry)
+		if entry.expiresAt.IsZero() || c.now().Before(entry.expiresAt) {
+			c.order.MoveToFront(element)
+			c.stats.Hits++
+			return entry.value, true
+		}
+		c.removeElement(element)
+	}
+
+	c.stats.Misses++
+	return nil, false
+}
+
+// generationNow returns the current generation, to be passed to set after
+// loading a value.
+func (c *lruCache) generationNow() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// set caches value unless the cache was invalidated since generation, in
+// which case the value may have been loaded before a write and be stale.
+func (c *lruCache) set(key string, value any, generation uint64) {
+	c.setUntil(key, value, generation, time.Time{})
+}
+
+// setUntil caches value as set does, but expires the entry no later than
+// until, unless until is zero. A value that has already expired replaces no
+// entry and is not cached.
+func (c *lruCache) setUntil(key string, value any, generation uint64, until time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	entry := &lruEntry{key: key, value: value}
+	if c.ttl > 0 {
+		entry.expiresAt = c.now().Add(c.ttl)
+	}
+	if !until.IsZero() {
+		if !c.now().Before(until) {
+			if element, ok := c.entries[key]; ok {
+				c.removeElement(element)
+			}
+			return
+		}
+		if entry.expiresAt.IsZero() || until.Before(entry.expiresAt) {
+			entry.expiresAt = until
+		}
+	}
+
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.maxEntries {
+		c.removeElement(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+// remove drops the entry cached under key.
+func (c *lruCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	if element, ok := c.entries[key]; ok {
+		c.removeElement(element)
+	}
+}
+
+// removeFunc drops every entry whose key satisfies match.
+func (c *lruCache) removeFunc(match func(key string) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for key, element := range c.entries {
+		if match(key) {
+			c.removeElement(element)
+		}
+	}
+}
+
+func (c *lruCache) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry).key)
+}
+
+func (c *lruCache) snapshot() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.order.Len()
+	return stats
+}
+
+func (c *lruCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.order.Init()
+	c.entries = map[string]*list.Element{}
+}
+
+// CachedStoreOptions configures a CachedStore
+type CachedStoreOptions struct {
+	CacheOptions
+	Bypass [][]string // Namespace prefixes whose items are never cached
+}
+
+// CachedStore is a Store that serves GetItem from a read-through cache.
+// PutItem and DeleteItem go to the underlying store and invalidate the
+// cached item; searches and namespace listings are not cached.
+//
+// A cache hit does not reach the server, so it does not refresh the item's
+// TTL there. GetItem calls with refreshTtl set to true always go to the
+// underlying store, and the refreshed item replaces the cached one. Entries
+// are served until the cache TTL passes or the item expires, whichever comes
+// first. Headers are not part of the cache key, so a cache must
+// not be shared between callers with different access rights.
+type CachedStore struct {
+	store  Store
+	cache  *lruCache
+	bypass [][]string
+}
+
+var _ Store = (*CachedStore)(nil)
+
+// NewCachedStore wraps store with a read-through cache.
+func NewCachedStore(store Store, opts CachedStoreOptions) *CachedStore {
+	return &CachedStore{store: store, cache: newLRUCache(opts.CacheOptions), bypass: opts.Bypass}
+}
+
+func (s *CachedStore) PutItem(ctx context.Context, namespace []string, key string, value map[string]any, index *any, ttl *time.Duration, headers *map[string]string) error {
+	// Invalidate even on failure, as the write may have been applied.
+	defer s.invalidate(namespace, key)
+	return s.store.PutItem(ctx, namespace, key, value, index, ttl, headers)
+}
+
+func (s *CachedStore) GetItem(ctx context.Context, namespace []string, key string, refreshTtl *bool, headers *map[string]string) (*schema.Item, error) {
+	if s.bypassed(namespace) {
+		return s.store.GetItem(ctx, namespace, key, refreshTtl, headers)
+	}
+
+	cacheKey := storeCacheKey(namespace, key)
+	if refreshTtl == nil || !*refreshTtl {
+		if cached, ok := s.cache.get(cacheKey); ok {
+			return copyItem(cached.(*schema.Item)), nil
+		}
+	}
+
+	generation := s.cache.generationNow()
+	item, err := s.store.GetItem(ctx, namespace, key, refreshTtl, headers)
+	if err != nil {
+		return nil, err
+	}
+	var until time.Time
+	if item.ExpiresAt != nil {
+		until = *item.ExpiresAt
+	}
+	s.cache.setUntil(cacheKey, copyItem(item), generation, until)
+
+	return item, nil
+}
+
+func (s *CachedStore) DeleteItem(ctx context.Context, namespace []string, key string, headers *map[string]string) error {
+	defer s.invalidate(namespace, key)
+	return s.store.DeleteItem(ctx, namespace, key, headers)
+}
+
+func (s *CachedStore) SearchItems(ctx context.Context, namespace []string, filter *map[string]any, limit *int, offset *int, query *string, refreshTtl *bool, headers *map[string]string) (schema.SearchItemsResponse, error) {
+	return s.store.SearchItems(ctx, namespace, filter, limit, offset, query, refreshTtl, headers)
+}
+
+func (s *CachedStore) ListNamespaces(ctx context.Context, prefix *[]string, suffix *[]string, maxDepth *int, limit *int, offset *int, headers *map[string]string) ([]schema.ListNamespaceResponse, error) {
+	return s.store.ListNamespaces(ctx, prefix, suffix, maxDepth, limit, offset, headers)
+}
+
+// Invalidate drops the cached items beneath a namespace prefix, e.g. after
+// the store was changed by another client.
+func (s *CachedStore) Invalidate(prefix []string) {
+	s.cache.removeFunc(func(key string) bool {
+		namespace, _, _ := strings.Cut(key, "\x00")
+		return isNamespacePrefix(prefix, strings.Split(namespace, "\x1f"))
+	})
+}
+
+// Stats returns the cache statistics.
+func (s *CachedStore) Stats() CacheStats {
+	return s.cache.snapshot()
+}
+
+// Purge empties the cache.
+func (s *CachedStore) Purge() {
+	s.cache.purge()
+}
+
+func (s *CachedStore) invalidate(namespace []string, key string) {
+	s.cache.remove(storeCacheKey(namespace, key))
+}
+
+func (s *CachedStore) bypassed(namespace []string) bool {
+	return slices.ContainsFunc(s.bypass, func(prefix []string) bool { return isNamespacePrefix(prefix, namespace) })
+}
+
+func storeCacheKey(namespace []string, key string) string {
+	return strings.Join(namespace, "\x1f") + "\x00" + key
+}
+
+func copyItem(item *schema.Item) *schema.Item {
+	copied := *item
+	copied.Namespace = append([]string{}, item.Namespace...)
+	copied.Value, _ = normalizeJSON(item.Value).(map[string]any)
+	if item.ExpiresAt != nil {
+		expiresAt := *item.ExpiresAt
+		copied.ExpiresAt = &expiresAt
+	}
+	return &copied
+}
+
+// CachedAssistants serves assistant metadata, schemas and graphs from a
+// read-through cache. Update, SetLatest and Delete go through to the server
+// and invalidate everything cached for the assistant.
+//
+// Cached values are shared between callers and must not be modified.
+// Headers are not part of the cache key, so a cache must not be shared
+// between callers with different access rights.
+type CachedAssistants struct {
+	client *AssistantsClient
+	cache  *lruCache
+}
+
+// NewCachedAssistants wraps client with a read-through cache.
+func NewCachedAssistants(client *AssistantsClient, opts CacheOptions) *CachedAssistants {
+	return &CachedAssistants{client: client, cache: newLRUCache(opts)}
+}
+
+func (c *CachedAssistants) Get(ctx context.Context, assistantID string, headers *map[string]string) (schema.Assistant, error) {
+	return cached(c.cache, assistantCacheKey(assistantID, "assistant"), func() (schema.Assistant, error) {
+		return c.client.Get(ctx, assistantID, headers)
+	})
+}
+
+func (c *CachedAssistants) GetSchemas(ctx context.Context, assistantID string, headers *map[string]string) (schema.GraphSchema, error) {
+	return cached(c.cache, assistantCacheKey(assistantID, "schemas"), func() (schema.GraphSchema, error) {
+		return c.client.GetSchemas(ctx, assistantID, headers)
+	})
+}
+
+func (c *CachedAssistants) GetGraph(ctx context.Context, assistantID string, xray *bool, headers *map[string]string) (schema.Graph, error) {
+	variant := "graph"
+	if xray != nil {
+		variant = fmt.Sprintf("graph:%t", *xray)
+	}
+	return cached(c.cache, assistantCacheKey(assistantID, variant), func() (schema.Graph, error) {
+		return c.client.GetGraph(ctx, assistantID, xray, headers)
+	})
+}
+
+func (c *CachedAssistants) Update(ctx context.Context, assistantID string, graphID *string, config *schema.Config, metadata *schema.Json, name *string, headers *map[string]string, description *string) (schema.Assistant, error) {
+	defer c.Invalidate(assistantID)
+	return c.client.Update(ctx, assistantID, graphID, config, metadata, name, headers, description)
+}
+
+func (c *CachedAssistants) SetLatest(ctx context.Context, assistantID string, version *int, headers *map[string]string) (schema.Assistant, error) {
+	defer c.Invalidate(assistantID)
+	return c.client.SetLatest(ctx, assistantID, version, headers)
+}
+
+func (c *CachedAssistants) Delete(ctx context.Context, assistantID string, headers *map[string]string) error {
+	defer c.Invalidate(assistantID)
+	return c.client.Delete(ctx, assistantID, headers)
+}
+
+// Invalidate drops everything cached for the assistant.
+func (c *CachedAssistants) Invalidate(assistantID string) {
+	prefix := assistantCacheKey(assistantID, "")
+	c.cache.removeFunc(func(key string) bool { return strings.HasPrefix(key, prefix) })
+}
+
+// Stats returns the cache statistics.
+func (c *CachedAssistants) Stats() CacheStats {
+	return c.cache.snapshot()
+}
+
+// Purge empties the cache.
+func (c *CachedAssistants) Purge() {
+	c.cache.purge()
+}
+
+func assistantCacheKey(assistantID string, variant string) string {
+	return assistantID + "\x00" + variant
+}
+
+// cached returns the value cached under key, or loads and caches it. Errors
+// are not cached.
+func cached[V any](cache *lruCache, key string, load func() (V, error)) (V, error) {
+	if value, ok := cache.get(key); ok {
+		return value.(V), nil
+	}
+
+	generation := cache.generationNow()
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	cache.set(key, value, generation)
+
+	return value, nil
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCache(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newLRUCache(CacheOptions{TTL: time.Minute, MaxEntries: 2})
+	cache.now = func() time.Time { return now }
+
+	cache.set("a", 1, cache.generationNow())
+	cache.set("b", 2, cache.generationNow())
+	_, ok := cache.get("a")
+	assert.True(t, ok)
+	cache.set("c", 3, cache.generationNow())
+
+	_, ok = cache.get("b")
+	assert.False(t, ok, "least recently used entry is evicted")
+
+	generation := cache.generationNow()
+	cache.remove("c")
+	cache.set("c", 4, generation)
+	_, ok = cache.get("c")
+	assert.False(t, ok, "values loaded before an invalidation are not cached")
+
+	now = now.Add(2 * time.Minute)
+	_, ok = cache.get("a")
+	assert.False(t, ok, "expired entry is not served")
+
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 3, Evictions: 1, Entries: 0}, cache.snapshot())
+}
+
+func TestCachedStore(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryStore(nil)
+	store := NewCachedStore(backend, CachedStoreOptions{Bypass: [][]string{{"sessions"}}})
+
+	assert.NoError(t, store.PutItem(ctx, []string{"users", "u1"}, "profile", map[string]any{"name": "Ada"}, nil, nil, nil))
+	item, err := store.GetItem(ctx, []string{"users", "u1"}, "profile", nil, nil)
+	assert.NoError(t, err)
+	item.Value["name"] = "changed by caller"
+
+	// A write to the backend behind the cache's back is not seen...
+	assert.NoError(t, backend.PutItem(ctx, []string{"users", "u1"}, "profile", map[string]any{"name": "Grace"}, nil, nil, nil))
+	item, err = store.GetItem(ctx, []string{"users", "u1"}, "profile", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Ada", item.Value["name"])
+
+	// ...until the item is written through the cache.
+	assert.NoError(t, store.PutItem(ctx, []string{"users", "u1"}, "profile", map[string]any{"name": "Linus"}, nil, nil, nil))
+	item, err = store.GetItem(ctx, []string{"users", "u1"}, "profile", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Linus", item.Value["name"])
+
+	assert.NoError(t, store.PutItem(ctx, []string{"sessions", "s1"}, "state", map[string]any{"step": 1}, nil, nil, nil))
+	_, err = store.GetItem(ctx, []string{"sessions", "s1"}, "state", nil, nil)
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.DeleteItem(ctx, []string{"users", "u1"}, "profile", nil))
+	_, err = store.GetItem(ctx, []string{"users", "u1"}, "profile", nil, nil)
+	assert.ErrorIs(t, err, ErrItemNotFound)
+
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 3, Entries: 0}, store.Stats())
+}
+
+func TestCachedStoreItemExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	backend := NewMemoryStore(&MemoryStoreOptions{Now: clock})
+	store := NewCachedStore(backend, CachedStoreOptions{CacheOptions: CacheOptions{TTL: 10 * time.Minute}})
+	store.cache.now = clock
+	namespace := []string{"sessions", "s1"}
+
+	ttl := 2 * time.Minute
+	assert.NoError(t, store.PutItem(ctx, namespace, "state", map[string]any{"step": 1}, nil, &ttl, nil))
+	_, err := store.GetItem(ctx, namespace, "state", nil, nil)
+	assert.NoError(t, err)
+
+	// The entry is served until the item expires, before the cache TTL.
+	now = now.Add(time.Minute)
+	_, err = store.GetItem(ctx, namespace, "state", nil, nil)
+	assert.NoError(t, err)
+	now = now.Add(90 * time.Second)
+	_, err = store.GetItem(ctx, namespace, "state", nil, nil)
+	assert.ErrorIs(t, err, ErrItemNotFound)
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 2, Entries: 0}, store.Stats())
+
+	// A refresh goes to the server and caches the item with its new expiry.
+	assert.NoError(t, store.PutItem(ctx, namespace, "state", map[string]any{"step": 2}, nil, &ttl, nil))
+	_, err = store.GetItem(ctx, namespace, "state", nil, nil)
+	assert.NoError(t, err)
+	now = now.Add(time.Minute)
+	refresh := true
+	item, err := store.GetItem(ctx, namespace, "state", &refresh, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(ttl), *item.ExpiresAt)
+	now = now.Add(90 * time.Second)
+	_, err = store.GetItem(ctx, namespace, "state", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, CacheStats{Hits: 2, Misses: 3, Entries: 1}, store.Stats())
+}
@@ -4,12 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/KhanhD1nh/langgraph-sdk-go/http"
 	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
 )
 
 type CronsClient struct {
+	// ValidateSchedules parses schedules with ParseCronSchedule before Create,
+	// CreateForThread and Update send them. It is off by default, since the
+	// server also accepts croniter extensions such as L, # and a seconds
+	// field, which ParseCronSchedule rejects.
+	ValidateSchedules bool
+
 	http *http.HttpClient
 }
 
@@ -17,55 +24,78 @@ func NewCronsClient(httpClient *http.HttpClient) *CronsClient {
 	return &CronsClient{http: httpClient}
 }
 
-func (c *CronsClient) CreatForThread(ctx context.Context, threadID string, assistantID string, schedule string, input *map[string]any, metadata *map[string]any, config *schema.Config, interruptBefore *any, interruptAfter *any, webhook *string, multitaskStrategy *schema.MultitaskStrategy, headers *map[string]string) (schema.Run, error) {
-	payload := map[string]any{
-		"schedule":         schedule,
-		"input":            input,
-		"config":           config,
-		"metadata":         metadata,
-		"assistant_id":     assistantID,
-		"interrupt_before": interruptBefore,
-		"interrupt_after":  interruptAfter,
-		"webhook":          webhook,
+func (c *CronsClient) CreateForThread(ctx context.Context, threadID string, assistantID string, schedule string, input *map[string]any, metadata *map[string]any, config *schema.Config, interruptBefore *[]string, interruptAfter *[]string, webhook *string, multitaskStrategy *schema.MultitaskStrategy, endTime *time.Time, enabled *bool, headers *map[string]string) (schema.Cron, error) {
+	if err := c.validateSchedule(&schedule); err != nil {
+		return schema.Cron{}, err
 	}
 
-	if multitaskStrategy != nil {
-		payload["multitask_strategy"] = *multitaskStrategy
+	payload := cronPayload(assistantID, schedule, input, metadata, config, interruptBefore, interruptAfter, webhook, multitaskStrategy, endTime, nil, enabled)
+
+	resp, err := c.http.Post(ctx, fmt.Sprintf("/threads/%s/runs/crons", threadID), payload, headers)
+	if err != nil {
+		return schema.Cron{}, err
 	}
 
-	payload, ok := removeEmptyFields(payload).(map[string]any)
-	if !ok {
-		fmt.Println("Error: cleanedPayload is not a map[string]any")
+	var cron schema.Cron
+	err = json.Unmarshal(resp.Body(), &cron)
+	if err != nil {
+		return schema.Cron{}, err
 	}
 
-	resp, err := c.http.Post(ctx, fmt.Sprintf("/threads/%s/crons", threadID), payload, headers)
+	return cron, nil
+}
+
+func (c *CronsClient) Create(ctx context.Context, assistantID string, schedule string, input *map[string]any, metadata *map[string]any, config *schema.Config, interruptBefore *[]string, interruptAfter *[]string, webhook *string, multitaskStrategy *schema.MultitaskStrategy, endTime *time.Time, onRunCompleted *schema.OnRunCompleted, enabled *bool, headers *map[string]string) (schema.Cron, error) {
+	if err := c.validateSchedule(&schedule); err != nil {
+		return schema.Cron{}, err
+	}
+
+	payload := cronPayload(assistantID, schedule, input, metadata, config, interruptBefore, interruptAfter, webhook, multitaskStrategy, endTime, onRunCompleted, enabled)
+
+	resp, err := c.http.Post(ctx, "/runs/crons", payload, headers)
 	if err != nil {
-		return schema.Run{}, err
+		return schema.Cron{}, err
 	}
 
-	var run schema.Run
-	err = json.Unmarshal(resp.Body(), &run)
+	var cron schema.Cron
+	err = json.Unmarshal(resp.Body(), &cron)
 	if err != nil {
-		return schema.Run{}, err
+		return schema.Cron{}, err
 	}
 
-	return run, nil
+	return cron, nil
 }
 
+// CreatForThread creates a cron job for a thread.
+//
+// Deprecated: use CreateForThread, which returns the created schema.Cron.
+func (c *CronsClient) CreatForThread(ctx context.Context, threadID string, assistantID string, schedule string, input *map[string]any, metadata *map[string]any, config *schema.Config, interruptBefore *any, interruptAfter *any, webhook *string, multitaskStrategy *schema.MultitaskStrategy, headers *map[string]string) (schema.Run, error) {
+	cron, err := c.CreateForThread(ctx, threadID, assistantID, schedule, input, metadata, config, interruptNodes(interruptBefore), interruptNodes(interruptAfter), webhook, multitaskStrategy, nil, nil, headers)
+	return cronRun(cron, multitaskStrategy), err
+}
+
+// Creat creates a cron job.
+//
+// Deprecated: use Create, which returns the created schema.Cron.
 func (c *CronsClient) Creat(ctx context.Context, assistantID string, schedule string, input *map[string]any, metadata *map[string]any, config *schema.Config, interruptBefore *schema.All, interruptAfter *schema.All, webhook *string, multitaskStrategy *schema.MultitaskStrategy, headers *map[string]string) (schema.Run, error) {
+	cron, err := c.Create(ctx, assistantID, schedule, input, metadata, config, interruptNodes(interruptBefore), interruptNodes(interruptAfter), webhook, multitaskStrategy, nil, nil, nil, headers)
+	return cronRun(cron, multitaskStrategy), err
+}
+
+func (c *CronsClient) Update(ctx context.Context, cronID string, schedule *string, endTime *time.Time, input *map[string]any, metadata *map[string]any, config *schema.Config, interruptBefore *[]string, interruptAfter *[]string, webhook *string, onRunCompleted *schema.OnRunCompleted, enabled *bool, headers *map[string]string) (schema.Cron, error) {
+	if err := c.validateSchedule(schedule); err != nil {
+		return schema.Cron{}, err
+	}
+
 	payload := map[string]any{
 		"schedule":         schedule,
 		"input":            input,
-		"config":           config,
 		"metadata":         metadata,
-		"assistant_id":     assistantID,
+		"config":           config,
 		"interrupt_before": interruptBefore,
 		"interrupt_after":  interruptAfter,
 		"webhook":          webhook,
-	}
-
-	if multitaskStrategy != nil {
-		payload["multitask_strategy"] = *multitaskStrategy
+		"on_run_completed": onRunCompleted,
 	}
 
 	payload, ok := removeEmptyFields(payload).(map[string]any)
@@ -73,22 +103,30 @@ func (c *CronsClient) Creat(ctx context.Context, assistantID string, schedule st
 		fmt.Println("Error: cleanedPayload is not a map[string]any")
 	}
 
-	resp, err := c.http.Post(ctx, "runs/crons", payload, headers)
+	// Set after cleaning so that enabled=false is still sent.
+	if endTime != nil {
+		payload["end_time"] = endTime.Format(time.RFC3339)
+	}
+	if enabled != nil {
+		payload["enabled"] = *enabled
+	}
+
+	resp, err := c.http.Patch(ctx, fmt.Sprintf("/runs/crons/%s", cronID), payload, headers)
 	if err != nil {
-		return schema.Run{}, err
+		return schema.Cron{}, err
 	}
 
-	var run schema.Run
-	err = json.Unmarshal(resp.Body(), &run)
+	var cron schema.Cron
+	err = json.Unmarshal(resp.Body(), &cron)
 	if err != nil {
-		return schema.Run{}, err
+		return schema.Cron{}, err
 	}
 
-	return run, nil
+	return cron, nil
 }
 
 func (c *CronsClient) Delete(ctx context.Context, cronID string, headers *map[string]string) error {
-	err := c.http.Delete(ctx, fmt.Sprintf("/crons/%s", cronID), nil, headers)
+	err := c.http.Delete(ctx, fmt.Sprintf("/runs/crons/%s", cronID), nil, headers)
 	if err != nil {
 		return err
 	}
@@ -96,7 +134,7 @@ func (c *CronsClient) Delete(ctx context.Context, cronID string, headers *map[st
 	return nil
 }
 
-func (c *CronsClient) Search(ctx context.Context, assistantID *string, threadID *string, limit *int, offset *int, headers *map[string]string) ([]schema.Cron, error) {
+func (c *CronsClient) Search(ctx context.Context, assistantID *string, threadID *string, limit *int, offset *int, sortBy *schema.CronSortBy, sortOrder *schema.SortOrder, selectFields *[]schema.CronSelectField, headers *map[string]string) ([]schema.Cron, error) {
 	if limit != nil && *limit <= 0 {
 		*limit = 10
 	}
@@ -106,10 +144,13 @@ func (c *CronsClient) Search(ctx context.Context, assistantID *string, threadID
 	}
 
 	payload := map[string]any{
-		"aasistant_id": assistantID,
+		"assistant_id": assistantID,
 		"thread_id":    threadID,
 		"limit":        limit,
 		"offset":       offset,
+		"sort_by":      sortBy,
+		"sort_order":   sortOrder,
+		"select":       selectFields,
 	}
 
 	payload, ok := removeEmptyFields(payload).(map[string]any)
@@ -117,7 +158,7 @@ func (c *CronsClient) Search(ctx context.Context, assistantID *string, threadID
 		fmt.Println("Error: cleanedPayload is not a map[string]any")
 	}
 
-	resp, err := c.http.Post(ctx, "runs/crons/search", payload, headers)
+	resp, err := c.http.Post(ctx, "/runs/crons/search", payload, headers)
 	if err != nil {
 		return []schema.Cron{}, err
 	}
@@ -131,3 +172,111 @@ func (c *CronsClient) Search(ctx context.Context, assistantID *string, threadID
 
 	return crons, nil
 }
+
+func (c *CronsClient) Count(ctx context.Context, assistantID *string, threadID *string, headers *map[string]string) (int, error) {
+	payload := map[string]any{
+		"assistant_id": assistantID,
+		"thread_id":    threadID,
+	}
+
+	payload, ok := removeEmptyFields(payload).(map[string]any)
+	if !ok {
+		fmt.Println("Error: cleanedPayload is not a map[string]any")
+	}
+
+	resp, err := c.http.Post(ctx, "/runs/crons/count", payload, headers)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = json.Unmarshal(resp.Body(), &count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// validateSchedule parses schedule if ValidateSchedules is set.
+func (c *CronsClient) validateSchedule(schedule *string) error {
+	if !c.ValidateSchedules || schedule == nil {
+		return nil
+	}
+	_, err := ParseCronSchedule(*schedule)
+	return err
+}
+
+func cronPayload(assistantID string, schedule string, input *map[string]any, metadata *map[string]any, config *schema.Config, interruptBefore *[]string, interruptAfter *[]string, webhook *string, multitaskStrategy *schema.MultitaskStrategy, endTime *time.Time, onRunCompleted *schema.OnRunCompleted, enabled *bool) map[string]any {
+	payload := map[string]any{
+		"schedule":         schedule,
+		"input":            input,
+		"config":           config,
+		"metadata":         metadata,
+		"assistant_id":     assistantID,
+		"interrupt_before": interruptBefore,
+		"interrupt_after":  interruptAfter,
+		"webhook":          webhook,
+		"on_run_completed": onRunCompleted,
+	}
+
+	if multitaskStrategy != nil {
+		payload["multitask_strategy"] = *multitaskStrategy
+	}
+
+	payload, ok := removeEmptyFields(payload).(map[string]any)
+	if !ok {
+		fmt.Println("Error: cleanedPayload is not a map[string]any")
+	}
+
+	// Set after cleaning so that enabled=false is still sent.
+	if endTime != nil {
+		payload["end_time"] = endTime.Format(time.RFC3339)
+	}
+	if enabled != nil {
+		payload["enabled"] = *enabled
+	}
+
+	return payload
+}
+
+// interruptNodes converts the interrupt arguments of the deprecated create
+// methods to a node list. A single name, including schema.AllWildcard, becomes
+// a one-element list.
+func interruptNodes[T any](nodes *T) *[]string {
+	if nodes == nil {
+		return nil
+	}
+
+	switch v := any(*nodes).(type) {
+	case []string:
+		return &v
+	case []any:
+		names := make([]string, 0, len(v))
+		for _, n := range v {
+			names = append(names, fmt.Sprint(n))
+		}
+		return &names
+	case schema.All:
+		return &[]string{string(v)}
+	case string:
+		return &[]string{v}
+	}
+	return nil
+}
+
+// cronRun fills the schema.Run returned by the deprecated create methods from
+// the created cron.
+func cronRun(cron schema.Cron, multitaskStrategy *schema.MultitaskStrategy) schema.Run {
+	run := schema.Run{CreatedAt: cron.CreatedAt, UpdatedAt: cron.UpdatedAt, Metadata: cron.Metadata}
+	if cron.AssistantID != nil {
+		run.AssistantID = *cron.AssistantID
+	}
+	if cron.ThreadID != nil {
+		run.ThreadID = *cron.ThreadID
+	}
+	if multitaskStrategy != nil {
+		run.MultitaskStrategy = *multitaskStrategy
+	}
+	return run
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/http"
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// cronRequest is a request received by a test crons client
+type cronRequest struct {
+	Method string
+	Path   string
+	Body   map[string]any
+}
+
+func newTestCronsClient(response string) (*CronsClient, *[]cronRequest) {
+	requests := []cronRequest{}
+	crons := NewCronsClient(http.NewHttpClient("http://crons.test", nil, 5*time.Second, roundTripFunc(func(req *nethttp.Request) (int, string) {
+		request := cronRequest{Method: req.Method, Path: req.URL.Path}
+		if req.Body != nil {
+			data, _ := io.ReadAll(req.Body)
+			json.Unmarshal(data, &request.Body)
+		}
+		requests = append(requests, request)
+		return 200, response
+	})))
+	return crons, &requests
+}
+
+func TestCronsUpdatePayload(t *testing.T) {
+	crons, requests := newTestCronsClient(`{"cron_id":"c1","enabled":false}`)
+
+	endTime := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	enabled := false
+	cron, err := crons.Update(context.Background(), "c1", nil, &endTime, nil, nil, nil, nil, nil, nil, nil, &enabled, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "c1", cron.CronID)
+
+	assert.Equal(t, []cronRequest{{
+		Method: "PATCH",
+		Path:   "/runs/crons/c1",
+		Body:   map[string]any{"enabled": false, "end_time": "2025-06-01T12:00:00Z"},
+	}}, *requests)
+
+	schedule := "0 9 * * 1"
+	*requests = nil
+	_, err = crons.Update(context.Background(), "c1", &schedule, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"schedule": schedule}, (*requests)[0].Body)
+}
+
+func TestCronsCreatePayload(t *testing.T) {
+	crons, requests := newTestCronsClient(`{"cron_id":"c1"}`)
+
+	enabled := false
+	_, err := crons.Create(context.Background(), "agent", "*/5 * * * *", nil, nil, nil, nil, nil, nil, nil, nil, nil, &enabled, nil)
+	assert.NoError(t, err)
+	_, err = crons.CreateForThread(context.Background(), "t1", "agent", "*/5 * * * *", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []cronRequest{
+		{Method: "POST", Path: "/runs/crons", Body: map[string]any{"assistant_id": "agent", "schedule": "*/5 * * * *", "enabled": false}},
+		{Method: "POST", Path: "/threads/t1/runs/crons", Body: map[string]any{"assistant_id": "agent", "schedule": "*/5 * * * *"}},
+	}, *requests)
+}
+
+func TestCronsScheduleValidation(t *testing.T) {
+	crons, requests := newTestCronsClient(`{"cron_id":"c1"}`)
+	ctx := context.Background()
+
+	// croniter extensions reach the server unless validation is enabled.
+	_, err := crons.Create(ctx, "agent", "0 0 L * *", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	_, err = crons.Creat(ctx, "agent", "0 0 * * 1#2", nil, nil, nil, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, *requests, 2)
+
+	crons.ValidateSchedules = true
+	_, err = crons.Create(ctx, "agent", "0 0 L * *", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	assert.ErrorContains(t, err, "invalid cron schedule")
+	schedule := "61 * * * *"
+	_, err = crons.Update(ctx, "c1", &schedule, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	assert.ErrorContains(t, err, "invalid cron schedule")
+	_, err = crons.CreatForThread(ctx, "t1", "agent", "0 0 L * *", nil, nil, nil, nil, nil, nil, nil, nil)
+	assert.ErrorContains(t, err, "invalid cron schedule")
+	assert.Len(t, *requests, 2)
+}
+
+func TestCronsDeprecatedCreate(t *testing.T) {
+	crons, requests := newTestCronsClient(`{"cron_id":"c1","assistant_id":"agent","thread_id":"t1","schedule":"*/5 * * * *","metadata":{"team":"ops"}}`)
+	ctx := context.Background()
+
+	all := schema.AllWildcard
+	strategy := schema.MultitaskStrategyEnqueue
+	run, err := crons.Creat(ctx, "agent", "*/5 * * * *", nil, nil, nil, &all, nil, nil, &strategy, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, schema.Run{AssistantID: "agent", ThreadID: "t1", Metadata: schema.Json{"team": "ops"}, MultitaskStrategy: strategy}, run)
+
+	var nodes any = []string{"agent"}
+	_, err = crons.CreatForThread(ctx, "t1", "agent", "*/5 * * * *", nil, nil, nil, nil, &nodes, nil, nil, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []cronRequest{
+		{Method: "POST", Path: "/runs/crons", Body: map[string]any{"assistant_id": "agent", "schedule": "*/5 * * * *", "interrupt_before": []any{"*"}, "multitask_strategy": "enqueue"}},
+		{Method: "POST", Path: "/threads/t1/runs/crons", Body: map[string]any{"assistant_id": "agent", "schedule": "*/5 * * * *", "interrupt_after": []any{"agent"}}},
+	}, *requests)
+}
+
+func TestCronsDelete(t *testing.T) {
+	crons, requests := newTestCronsClient(``)
+
+	assert.NoError(t, crons.Delete(context.Background(), "c1", nil))
+	assert.Equal(t, "DELETE", (*requests)[0].Method)
+	assert.Equal(t, "/runs/crons/c1", (*requests)[0].Path)
+}
+
+func TestCronsSearchAndCount(t *testing.T) {
+	crons, requests := newTestCronsClient(`[{"cron_id":"c1"}]`)
+
+	assistantID := "agent"
+	sortBy := schema.CronSortByNextRunDate
+	sortOrder := schema.SortOrderAsc
+	fields := []schema.CronSelectField{schema.CronSelectFieldCronID}
+	limit := 5
+	found, err := crons.Search(context.Background(), &assistantID, nil, &limit, nil, &sortBy, &sortOrder, &fields, nil)
+	assert.NoError(t, err)
+	assert.Len(t, found, 1)
+	assert.Equal(t, cronRequest{
+		Method: "POST",
+		Path:   "/runs/crons/search",
+		Body: map[string]any{
+			"assistant_id": "agent",
+			"limit":        float64(5),
+			"sort_by":      "next_run_date",
+			"sort_order":   "asc",
+			"select":       []any{"cron_id"},
+		},
+	}, (*requests)[0])
+
+	crons, requests = newTestCronsClient(`3`)
+	threadID := "t1"
+	count, err := crons.Count(context.Background(), nil, &threadID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, cronRequest{Method: "POST", Path: "/runs/crons/count", Body: map[string]any{"thread_id": "t1"}}, (*requests)[0])
+}
@@ -0,0 +1,282 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// CronSchedule is a parsed five-field cron expression (minute, hour, day of
+// month, month, day of week), evaluated in a configurable time zone
+type CronSchedule struct {
+	expr     string
+	minute   uint64
+	hour     uint64
+	dom      uint64
+	month    uint64
+	dow      uint64
+	domStar  bool
+	dowStar  bool
+	location *time.Location
+}
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	// 7 is accepted as an alias for Sunday.
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCronSchedule parses a five-field cron expression or one of the macros
+// @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly. The
+// schedule is evaluated in UTC, like on the server, unless changed with In.
+// croniter extensions accepted by the server, such as L, #, ? and a seconds
+// field, are not supported.
+func ParseCronSchedule(expr string) (*CronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if strings.HasPrefix(spec, "@") {
+		macro, ok := cronMacros[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("invalid cron schedule '%s': unknown macro", expr)
+		}
+		spec = macro
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron schedule '%s': expected 5 fields, got %d", expr, len(parts))
+	}
+
+	bits := make([]uint64, len(cronFields))
+	for i, part := range parts {
+		b, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron schedule '%s': %w", expr, err)
+		}
+		bits[i] = b
+	}
+
+	// Fold the Sunday alias onto 0.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+
+	return &CronSchedule{
+		expr:     expr,
+		minute:   bits[0],
+		hour:     bits[1],
+		dom:      bits[2],
+		month:    bits[3],
+		dow:      bits[4],
+		domStar:  strings.HasPrefix(parts[2], "*"),
+		dowStar:  strings.HasPrefix(parts[4], "*"),
+		location: time.UTC,
+	}, nil
+}
+
+// In returns a copy of the schedule evaluated in the given time zone.
+func (s *CronSchedule) In(location *time.Location) *CronSchedule {
+	copied := *s
+	copied.location = location
+	return &copied
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *CronSchedule) String() string {
+	return s.expr
+}
+
+// Next returns the first fire time strictly after the given time, or the zero
+// time if the schedule never fires (e.g. "0 0 30 2 *").
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	t := after.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	// Every valid schedule fires within a leap year cycle.
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = advance(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location))
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = advance(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location))
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// NextN returns the next n fire times after the given time.
+func (s *CronSchedule) NextN(after time.Time, n int) []time.Time {
+	times := make([]time.Time, 0, n)
+	for len(times) < n {
+		next := s.Next(after)
+		if next.IsZero() {
+			break
+		}
+		times = append(times, next)
+		after = next
+	}
+	return times
+}
+
+// advance moves t forward to next. When next falls into a daylight saving
+// gap, time.Date may normalise it to before t; t then moves to the next hour.
+func advance(t time.Time, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+	return t.Add(time.Duration(60-t.Minute()) * time.Minute)
+}
+
+// dayMatches applies the cron rule that when both day fields are restricted,
+// a day matches if either of them does.
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		rangePart, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			rangePart = item[:i]
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field '%s'", spec.name, item)
+			}
+			step = n
+		}
+
+		var lo, hi int
+		switch {
+		case rangePart == "*":
+			lo, hi = spec.min, spec.max
+			if spec.name == "day of week" {
+				hi = 6
+			}
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = cronValue(bounds[0], spec); err != nil {
+				return 0, err
+			}
+			if hi, err = cronValue(bounds[1], spec); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range in %s field '%s'", spec.name, item)
+			}
+		default:
+			var err error
+			if lo, err = cronValue(rangePart, spec); err != nil {
+				return 0, err
+			}
+			hi = lo
+			// "5/15" means every 15 starting at 5.
+			if step > 1 {
+				hi = spec.max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func cronValue(value string, spec cronField) (int, error) {
+	if n, ok := spec.names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value '%s'", spec.name, value)
+	}
+	if n < spec.min || n > spec.max {
+		return 0, fmt.Errorf("%s value %d out of range %d-%d", spec.name, n, spec.min, spec.max)
+	}
+	return n, nil
+}
+
+// AnnotateNextRuns fills in the NextRuns field of each cron with its next n
+// fire times after now, evaluated in location (UTC if nil). Disabled crons and
+// fire times past a cron's end time are left out. Crons whose schedule
+// ParseCronSchedule cannot parse, such as ones using croniter extensions,
+// keep a nil NextRuns; their errors are joined into the returned error.
+func AnnotateNextRuns(crons []schema.Cron, n int, location *time.Location) error {
+	if location == nil {
+		location = time.UTC
+	}
+
+	now := time.Now()
+	var errs []error
+	for i := range crons {
+		cron := &crons[i]
+		cron.NextRuns = nil
+		if cron.Enabled != nil && !*cron.Enabled {
+			cron.NextRuns = []time.Time{}
+			continue
+		}
+
+		schedule, err := ParseCronSchedule(cron.Schedule)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cron '%s': %w", cron.CronID, err))
+			continue
+		}
+
+		cron.NextRuns = []time.Time{}
+		for _, next := range schedule.In(location).NextN(now, n) {
+			if cron.EndTime != nil && next.After(*cron.EndTime) {
+				break
+			}
+			cron.NextRuns = append(cron.NextRuns, next)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	after := time.Date(2025, 1, 31, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2025, 1, 31, 10, 30, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, 1, 31, 11, 0, 0, 0, time.UTC)},
+		{"0 9 * * mon-fri", time.Date(2025, 2, 3, 9, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"30 8 1 * 7", time.Date(2025, 2, 1, 8, 30, 0, 0, time.UTC)},
+		{"5/20 10 * jan *", time.Date(2025, 1, 31, 10, 25, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		schedule, err := ParseCronSchedule(tt.expr)
+		assert.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.want, schedule.Next(after), tt.expr)
+	}
+}
+
+func TestCronScheduleNextNInLocation(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	schedule, err := ParseCronSchedule("0 9 * * *")
+	assert.NoError(t, err)
+
+	times := schedule.In(location).NextN(time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC), 2)
+
+	assert.Equal(t, []time.Time{
+		time.Date(2025, 3, 8, 14, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 9, 13, 0, 0, 0, time.UTC),
+	}, []time.Time{times[0].UTC(), times[1].UTC()})
+}
+
+func TestParseCronScheduleErrors(t *testing.T) {
+	for _, expr := range []string{"* * * *", "60 * * * *", "* * * * funday", "*/0 * * * *", "@fortnightly", "5-1 * * * *"} {
+		_, err := ParseCronSchedule(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestAnnotateNextRuns(t *testing.T) {
+	disabled := false
+	end := time.Now().UTC().Truncate(time.Hour).Add(90 * time.Minute)
+	crons := []schema.Cron{
+		{CronID: "a", Schedule: "@hourly", EndTime: &end},
+		{CronID: "b", Schedule: "@daily", Enabled: &disabled},
+	}
+
+	assert.NoError(t, AnnotateNextRuns(crons, 5, nil))
+	assert.Len(t, crons[0].NextRuns, 1)
+	assert.Empty(t, crons[1].NextRuns)
+
+	crons = []schema.Cron{
+		{CronID: "last-day", Schedule: "0 0 L * *"},
+		{CronID: "c", Schedule: "@daily"},
+		{CronID: "seconds", Schedule: "0 0 * * * 30"},
+	}
+	err := AnnotateNextRuns(crons, 2, nil)
+	assert.ErrorContains(t, err, "cron 'last-day'")
+	assert.ErrorContains(t, err, "cron 'seconds'")
+	assert.Nil(t, crons[0].NextRuns)
+	assert.Len(t, crons[1].NextRuns, 2)
+	assert.Nil(t, crons[2].NextRuns)
+}
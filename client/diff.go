@@ -0,0 +1,333 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// StateChangeKind describes how a value changed between two thread states
+type StateChangeKind string
+
+const (
+	StateChangeAdded   StateChangeKind = "added"   // The path exists only in the newer state
+	StateChangeRemoved StateChangeKind = "removed" // The path exists only in the older state
+	StateChangeChanged StateChangeKind = "changed" // The path exists in both states with different values
+)
+
+// StateChange is a single difference between two thread states
+type StateChange struct {
+	Kind      StateChangeKind `json:"kind"`                 // How the value changed
+	Path      string          `json:"path"`                 // JSON Pointer (RFC 6901) to the value
+	From      any             `json:"from,omitempty"`       // The value in the older state
+	To        any             `json:"to,omitempty"`         // The value in the newer state
+	MessageID string          `json:"message_id,omitempty"` // The message ID, when the path points into a messages list
+}
+
+// StateDiff is the structured difference between the values of two thread states
+type StateDiff struct {
+	FromCheckpoint string        `json:"from_checkpoint"` // The checkpoint ID of the older state
+	ToCheckpoint   string        `json:"to_checkpoint"`   // The checkpoint ID of the newer state
+	Changes        []StateChange `json:"changes"`         // The differences, in JSON Patch application order
+}
+
+// PatchOperation is a single RFC 6902 JSON Patch operation
+type PatchOperation struct {
+	Op    string `json:"op"`    // One of "add", "remove" or "replace"
+	Path  string `json:"path"`  // JSON Pointer to the target location
+	Value any    `json:"value"` // The value to add or replace with
+}
+
+func (p PatchOperation) MarshalJSON() ([]byte, error) {
+	if p.Op == "remove" {
+		return json.Marshal(map[string]any{"op": p.Op, "path": p.Path})
+	}
+	return json.Marshal(map[string]any{"op": p.Op, "path": p.Path, "value": p.Value})
+}
+
+// DiffStates compares the values of two thread states. Lists under a "messages"
+// key whose entries carry an "id" are matched by message ID rather than position.
+func DiffStates(from schema.ThreadState, to schema.ThreadState) StateDiff {
+	diff := StateDiff{
+		FromCheckpoint: checkpointIDOf(from.Checkpoint),
+		ToCheckpoint:   checkpointIDOf(to.Checkpoint),
+		Changes:        []StateChange{},
+	}
+	diffValues(normalizeJSON(from.Values), normalizeJSON(to.Values), "", "", &diff.Changes)
+	return diff
+}
+
+// DiffHistory diffs consecutive entries of a thread history as returned by
+// ThreadsClient.GetHistory (newest first). The result is ordered oldest first.
+func DiffHistory(history []schema.ThreadState) []StateDiff {
+	diffs := []StateDiff{}
+	for i := len(history) - 1; i > 0; i-- {
+		diffs = append(diffs, DiffStates(history[i], history[i-1]))
+	}
+	return diffs
+}
+
+// DiffCheckpoints fetches two checkpoints of a thread and diffs their values.
+func (c *ThreadsClient) DiffCheckpoints(ctx context.Context, threadID string, fromCheckpointID string, toCheckpointID string, headers *map[string]string) (StateDiff, error) {
+	from, err := c.GetState(ctx, threadID, nil, &fromCheckpointID, nil, headers)
+	if err != nil {
+		return StateDiff{}, err
+	}
+
+	to, err := c.GetState(ctx, threadID, nil, &toCheckpointID, nil, headers)
+	if err != nil {
+		return StateDiff{}, err
+	}
+
+	return DiffStates(from, to), nil
+}
+
+// IsEmpty reports whether the two states had identical values.
+func (d StateDiff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// Patch converts the diff to RFC 6902 JSON Patch operations that transform the
+// older state's values into the newer state's values.
+func (d StateDiff) Patch() []PatchOperation {
+	ops := make([]PatchOperation, 0, len(d.Changes))
+	for _, change := range d.Changes {
+		switch change.Kind {
+		case StateChangeAdded:
+			ops = append(ops, PatchOperation{Op: "add", Path: change.Path, Value: change.To})
+		case StateChangeRemoved:
+			ops = append(ops, PatchOperation{Op: "remove", Path: change.Path})
+		case StateChangeChanged:
+			ops = append(ops, PatchOperation{Op: "replace", Path: change.Path, Value: change.To})
+		}
+	}
+	return ops
+}
+
+// JSONPatch returns the diff encoded as an RFC 6902 JSON Patch document.
+func (d StateDiff) JSONPatch() ([]byte, error) {
+	return json.Marshal(d.Patch())
+}
+
+// String renders the diff as human readable text, one change per line.
+func (d StateDiff) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", d.FromCheckpoint, d.ToCheckpoint)
+	for _, change := range d.Changes {
+		path := change.Path
+		if path == "" {
+			path = "/"
+		}
+		if change.MessageID != "" {
+			path = fmt.Sprintf("%s (message %s)", path, change.MessageID)
+		}
+		switch change.Kind {
+		case StateChangeAdded:
+			fmt.Fprintf(&b, "+ %s: %s\n", path, renderValue(change.To))
+		case StateChangeRemoved:
+			fmt.Fprintf(&b, "- %s: %s\n", path, renderValue(change.From))
+		case StateChangeChanged:
+			fmt.Fprintf(&b, "~ %s: %s -> %s\n", path, renderValue(change.From), renderValue(change.To))
+		}
+	}
+	return b.String()
+}
+
+func diffValues(from any, to any, path string, messageID string, changes *[]StateChange) {
+	switch f := from.(type) {
+	case map[string]any:
+		if t, ok := to.(map[string]any); ok {
+			diffMaps(f, t, path, messageID, changes)
+			return
+		}
+	case []any:
+		if t, ok := to.([]any); ok {
+			if strings.HasSuffix(path, "/messages") && diffMessages(f, t, path, changes) {
+				return
+			}
+			diffLists(f, t, path, messageID, changes)
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*changes = append(*changes, StateChange{Kind: StateChangeChanged, Path: path, From: from, To: to, MessageID: messageID})
+	}
+}
+
+func diffMaps(from map[string]any, to map[string]any, path string, messageID string, changes *[]StateChange) {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		childPath := path + "/" + escapePointer(k)
+		f, inFrom := from[k]
+		t, inTo := to[k]
+		switch {
+		case !inTo:
+			*changes = append(*changes, StateChange{Kind: StateChangeRemoved, Path: childPath, From: f, MessageID: messageID})
+		case !inFrom:
+			*changes = append(*changes, StateChange{Kind: StateChangeAdded, Path: childPath, To: t, MessageID: messageID})
+		default:
+			diffValues(f, t, childPath, messageID, changes)
+		}
+	}
+}
+
+func diffLists(from []any, to []any, path string, messageID string, changes *[]StateChange) {
+	common := min(len(from), len(to))
+	for i := 0; i < common; i++ {
+		diffValues(from[i], to[i], path+"/"+strconv.Itoa(i), messageID, changes)
+	}
+	// Remove trailing entries from the end so that earlier indexes stay valid.
+	for i := len(from) - 1; i >= common; i-- {
+		*changes = append(*changes, StateChange{Kind: StateChangeRemoved, Path: path + "/" + strconv.Itoa(i), From: from[i], MessageID: messageID})
+	}
+	for i := common; i < len(to); i++ {
+		*changes = append(*changes, StateChange{Kind: StateChangeAdded, Path: path + "/" + strconv.Itoa(i), To: to[i], MessageID: messageID})
+	}
+}
+
+// diffMessages matches messages by ID. It returns false when the lists cannot
+// be matched that way (missing or duplicate IDs), so the caller falls back to
+// a positional diff. Reordered messages replace the whole list.
+func diffMessages(from []any, to []any, path string, changes *[]StateChange) bool {
+	fromIDs, ok := messageIDs(from)
+	if !ok {
+		return false
+	}
+	toIDs, ok := messageIDs(to)
+	if !ok {
+		return false
+	}
+
+	fromIndex := make(map[string]int, len(fromIDs))
+	for i, id := range fromIDs {
+		fromIndex[id] = i
+	}
+	toIndex := make(map[string]int, len(toIDs))
+	for i, id := range toIDs {
+		toIndex[id] = i
+	}
+
+	var keptFrom, keptTo []string
+	for _, id := range fromIDs {
+		if _, ok := toIndex[id]; ok {
+			keptFrom = append(keptFrom, id)
+		}
+	}
+	for _, id := range toIDs {
+		if _, ok := fromIndex[id]; ok {
+			keptTo = append(keptTo, id)
+		}
+	}
+	if !reflect.DeepEqual(keptFrom, keptTo) {
+		*changes = append(*changes, StateChange{Kind: StateChangeChanged, Path: path, From: from, To: to})
+		return true
+	}
+
+	for i := len(fromIDs) - 1; i >= 0; i-- {
+		if _, ok := toIndex[fromIDs[i]]; !ok {
+			*changes = append(*changes, StateChange{Kind: StateChangeRemoved, Path: path + "/" + strconv.Itoa(i), From: from[i], MessageID: fromIDs[i]})
+		}
+	}
+	for j, id := range toIDs {
+		itemPath := path + "/" + strconv.Itoa(j)
+		i, ok := fromIndex[id]
+		if !ok {
+			*changes = append(*changes, StateChange{Kind: StateChangeAdded, Path: itemPath, To: to[j], MessageID: id})
+			continue
+		}
+		diffValues(from[i], to[j], itemPath, id, changes)
+	}
+
+	return true
+}
+
+func messageIDs(messages []any) ([]string, bool) {
+	ids := make([]string, 0, len(messages))
+	seen := make(map[string]bool, len(messages))
+	for _, m := range messages {
+		msg, ok := m.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		id, ok := msg["id"].(string)
+		if !ok || id == "" || seen[id] {
+			return nil, false
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids, true
+}
+
+// normalizeJSON round-trips typed values through JSON so that diffing only
+// has to deal with maps, slices and scalars.
+func normalizeJSON(value any) any {
+	switch value.(type) {
+	case nil, map[string]any, []any, string, float64, bool:
+		return normalizeNested(value)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
+
+func normalizeNested(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = normalizeJSON(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = normalizeJSON(item)
+		}
+		return out
+	}
+	return value
+}
+
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func renderValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+func checkpointIDOf(checkpoint schema.Checkpoint) string {
+	if checkpoint.CheckpointID == nil {
+		return ""
+	}
+	return *checkpoint.CheckpointID
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func stateWith(checkpointID string, values map[string]any) schema.ThreadState {
+	return schema.ThreadState{
+		Values:     values,
+		Checkpoint: schema.Checkpoint{CheckpointID: &checkpointID},
+	}
+}
+
+func TestDiffStates(t *testing.T) {
+	from := stateWith("1", map[string]any{
+		"step":  1,
+		"draft": "hello",
+		"messages": []any{
+			map[string]any{"id": "a", "content": "hi"},
+			map[string]any{"id": "b", "content": "thinking"},
+			map[string]any{"id": "c", "content": "ok"},
+		},
+	})
+	to := stateWith("2", map[string]any{
+		"step":  2,
+		"final": true,
+		"messages": []any{
+			map[string]any{"id": "a", "content": "hi"},
+			map[string]any{"id": "c", "content": "ok!"},
+			map[string]any{"id": "d", "content": "done"},
+		},
+	})
+
+	diff := DiffStates(from, to)
+
+	assert.Equal(t, "1", diff.FromCheckpoint)
+	assert.Equal(t, "2", diff.ToCheckpoint)
+	assert.Equal(t, []PatchOperation{
+		{Op: "remove", Path: "/draft"},
+		{Op: "add", Path: "/final", Value: true},
+		{Op: "remove", Path: "/messages/1"},
+		{Op: "replace", Path: "/messages/1/content", Value: "ok!"},
+		{Op: "add", Path: "/messages/2", Value: map[string]any{"id": "d", "content": "done"}},
+		{Op: "replace", Path: "/step", Value: float64(2)},
+	}, diff.Patch())
+	assert.Equal(t, "c", diff.Changes[3].MessageID)
+
+	patch, err := diff.JSONPatch()
+	assert.NoError(t, err)
+	assert.Contains(t, string(patch), `{"op":"remove","path":"/draft"}`)
+
+	assert.Contains(t, diff.String(), "~ /messages/1/content (message c): \"ok\" -> \"ok!\"")
+}
+
+func TestDiffStatesReorderedMessages(t *testing.T) {
+	from := stateWith("1", map[string]any{"messages": []any{
+		map[string]any{"id": "a"},
+		map[string]any{"id": "b"},
+	}})
+	to := stateWith("2", map[string]any{"messages": []any{
+		map[string]any{"id": "b"},
+		map[string]any{"id": "a"},
+	}})
+
+	ops := DiffStates(from, to).Patch()
+
+	assert.Len(t, ops, 1)
+	assert.Equal(t, "replace", ops[0].Op)
+	assert.Equal(t, "/messages", ops[0].Path)
+}
+
+func TestDiffHistory(t *testing.T) {
+	history := []schema.ThreadState{
+		stateWith("3", map[string]any{"n": 3}),
+		stateWith("2", map[string]any{"n": 2}),
+		stateWith("1", map[string]any{"n": 2}),
+	}
+
+	diffs := DiffHistory(history)
+
+	assert.Len(t, diffs, 2)
+	assert.True(t, diffs[0].IsEmpty())
+	assert.Equal(t, "2", diffs[1].FromCheckpoint)
+	assert.Equal(t, "3", diffs[1].ToCheckpoint)
+}
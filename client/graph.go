@@ -0,0 +1,188 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// Names of the virtual entry and exit nodes of every graph.
+const (
+	StartNode = "__start__"
+	EndNode   = "__end__"
+)
+
+// subgraphSeparator separates a subgraph's name from its inner node IDs in
+// graphs fetched with xray enabled.
+const subgraphSeparator = ":"
+
+// RenderMermaid renders a graph as a Mermaid flowchart. Conditional edges are
+// drawn dotted, and the nodes of xray subgraphs are grouped into subgraphs.
+func RenderMermaid(graph schema.Graph) string {
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+
+	root := clusterNodes(graph)
+	var writeCluster func(cluster *graphCluster, indent string)
+	writeCluster = func(cluster *graphCluster, indent string) {
+		for _, node := range cluster.nodes {
+			label := mermaidEscape(baseName(node.ID))
+			id := mermaidID(node.ID)
+			switch baseName(node.ID) {
+			case StartNode:
+				fmt.Fprintf(&b, "%s%s([%s]):::first\n", indent, id, label)
+			case EndNode:
+				fmt.Fprintf(&b, "%s%s([%s]):::last\n", indent, id, label)
+			default:
+				fmt.Fprintf(&b, "%s%s(%s)\n", indent, id, label)
+			}
+		}
+		for _, child := range cluster.children {
+			fmt.Fprintf(&b, "%ssubgraph %s [%s]\n", indent, mermaidID(child.name), mermaidEscape(child.label))
+			writeCluster(child, indent+"\t")
+			fmt.Fprintf(&b, "%send\n", indent)
+		}
+	}
+	writeCluster(root, "\t")
+
+	for _, edge := range graph.Edges {
+		source, target := mermaidID(edge.Source), mermaidID(edge.Target)
+		label := edgeLabel(edge)
+		switch {
+		case edge.Conditional && label != "":
+			fmt.Fprintf(&b, "\t%s -. %s .-> %s;\n", source, mermaidEscape(label), target)
+		case edge.Conditional:
+			fmt.Fprintf(&b, "\t%s -.-> %s;\n", source, target)
+		case label != "":
+			fmt.Fprintf(&b, "\t%s -- %s --> %s;\n", source, mermaidEscape(label), target)
+		default:
+			fmt.Fprintf(&b, "\t%s --> %s;\n", source, target)
+		}
+	}
+
+	b.WriteString("\tclassDef default fill:#f2f0ff,line-height:1.2\n")
+	b.WriteString("\tclassDef first fill-opacity:0\n")
+	b.WriteString("\tclassDef last fill:#bfb6fc\n")
+
+	return b.String()
+}
+
+// RenderDOT renders a graph in the Graphviz DOT language. Conditional edges
+// are drawn dashed, and the nodes of xray subgraphs are grouped into clusters.
+func RenderDOT(graph schema.Graph) string {
+	var b strings.Builder
+	b.WriteString("digraph G {\n")
+	b.WriteString("\trankdir=TB;\n")
+	b.WriteString("\tnode [shape=box, style=rounded];\n")
+
+	root := clusterNodes(graph)
+	clusterCount := 0
+	var writeCluster func(cluster *graphCluster, indent string)
+	writeCluster = func(cluster *graphCluster, indent string) {
+		for _, node := range cluster.nodes {
+			attrs := fmt.Sprintf("label=%s", dotQuote(baseName(node.ID)))
+			switch baseName(node.ID) {
+			case StartNode, EndNode:
+				attrs += ", shape=oval, style=filled, fillcolor=lightgrey"
+			}
+			fmt.Fprintf(&b, "%s%s [%s];\n", indent, dotQuote(node.ID), attrs)
+		}
+		for _, child := range cluster.children {
+			fmt.Fprintf(&b, "%ssubgraph cluster_%d {\n", indent, clusterCount)
+			clusterCount++
+			fmt.Fprintf(&b, "%s\tlabel=%s;\n", indent, dotQuote(child.label))
+			writeCluster(child, indent+"\t")
+			fmt.Fprintf(&b, "%s}\n", indent)
+		}
+	}
+	writeCluster(root, "\t")
+
+	for _, edge := range graph.Edges {
+		attrs := []string{}
+		if edge.Conditional {
+			attrs = append(attrs, "style=dashed")
+		}
+		if label := edgeLabel(edge); label != "" {
+			attrs = append(attrs, "label="+dotQuote(label))
+		}
+		if len(attrs) > 0 {
+			fmt.Fprintf(&b, "\t%s -> %s [%s];\n", dotQuote(edge.Source), dotQuote(edge.Target), strings.Join(attrs, ", "))
+		} else {
+			fmt.Fprintf(&b, "\t%s -> %s;\n", dotQuote(edge.Source), dotQuote(edge.Target))
+		}
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// graphCluster groups the nodes sharing a subgraph prefix.
+type graphCluster struct {
+	name     string
+	label    string
+	nodes    []schema.Node
+	children []*graphCluster
+	byName   map[string]*graphCluster
+}
+
+func clusterNodes(graph schema.Graph) *graphCluster {
+	root := &graphCluster{byName: map[string]*graphCluster{}}
+	for _, node := range graph.Nodes {
+		parts := strings.Split(node.ID, subgraphSeparator)
+		cluster := root
+		for i := 0; i < len(parts)-1; i++ {
+			name := strings.Join(parts[:i+1], subgraphSeparator)
+			child, ok := cluster.byName[name]
+			if !ok {
+				child = &graphCluster{name: name, label: parts[i], byName: map[string]*graphCluster{}}
+				cluster.byName[name] = child
+				cluster.children = append(cluster.children, child)
+			}
+			cluster = child
+		}
+		cluster.nodes = append(cluster.nodes, node)
+	}
+	return root
+}
+
+func baseName(nodeID string) string {
+	if i := strings.LastIndex(nodeID, subgraphSeparator); i >= 0 {
+		return nodeID[i+1:]
+	}
+	return nodeID
+}
+
+func edgeLabel(edge schema.Edge) string {
+	switch data := edge.Data.(type) {
+	case string:
+		return data
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", data)
+	}
+}
+
+// mermaidID maps a node ID onto the characters Mermaid accepts in identifiers.
+func mermaidID(id string) string {
+	var b strings.Builder
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			b.WriteRune(r)
+		default:
+			fmt.Fprintf(&b, "_%x_", r)
+		}
+	}
+	return b.String()
+}
+
+func mermaidEscape(label string) string {
+	return `"` + strings.ReplaceAll(label, `"`, "#quot;") + `"`
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
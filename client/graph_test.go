@@ -0,0 +1,47 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func testGraph() schema.Graph {
+	return schema.Graph{
+		Nodes: []schema.Node{
+			{ID: "__start__", Type: "schema"},
+			{ID: "agent", Type: "runnable"},
+			{ID: "tools:search", Type: "runnable"},
+			{ID: "tools:fetch", Type: "runnable"},
+			{ID: "__end__", Type: "schema"},
+		},
+		Edges: []schema.Edge{
+			{Source: "__start__", Target: "agent"},
+			{Source: "agent", Target: "tools:search", Conditional: true, Data: "search"},
+			{Source: "tools:search", Target: "tools:fetch"},
+			{Source: "tools:fetch", Target: "agent"},
+			{Source: "agent", Target: "__end__", Conditional: true},
+		},
+	}
+}
+
+func TestRenderMermaid(t *testing.T) {
+	out := RenderMermaid(testGraph())
+
+	assert.Contains(t, out, "flowchart TD\n")
+	assert.Contains(t, out, "\t__start__([\"__start__\"]):::first\n")
+	assert.Contains(t, out, "\tsubgraph tools [\"tools\"]\n\t\ttools_3a_search(\"search\")\n\t\ttools_3a_fetch(\"fetch\")\n\tend\n")
+	assert.Contains(t, out, "\tagent -. \"search\" .-> tools_3a_search;\n")
+	assert.Contains(t, out, "\tagent -.-> __end__;\n")
+	assert.Contains(t, out, "\t__start__ --> agent;\n")
+}
+
+func TestRenderDOT(t *testing.T) {
+	out := RenderDOT(testGraph())
+
+	assert.Contains(t, out, "digraph G {\n")
+	assert.Contains(t, out, "\tsubgraph cluster_0 {\n\t\tlabel=\"tools\";\n\t\t\"tools:search\" [label=\"search\"];\n")
+	assert.Contains(t, out, "\t\"agent\" -> \"tools:search\" [style=dashed, label=\"search\"];\n")
+	assert.Contains(t, out, "\t\"__start__\" -> \"agent\";\n")
+}
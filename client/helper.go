@@ -1,6 +1,7 @@
 package client
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 )
@@ -77,3 +78,12 @@ func removeEmptyFields(data any) any {
 func containsDot(s string) bool {
 	return strings.Contains(s, ".")
 }
+
+func validateNamespace(namespace []string) error {
+	for _, label := range namespace {
+		if containsDot(label) {
+			return fmt.Errorf("invalid namespace label '%s'. Namespace labels cannot contain periods ('.')", label)
+		}
+	}
+	return nil
+}
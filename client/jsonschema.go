@@ -0,0 +1,340 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldError is a single JSON Schema violation
+type FieldError struct {
+	Path    string // JSON Pointer to the offending value; empty for the root
+	Message string // What is wrong with the value
+}
+
+func (e FieldError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationError lists every JSON Schema violation found in a value
+type ValidationError struct {
+	Subject string       // What was validated, e.g. "input" or "config.configurable"
+	Fields  []FieldError // The individual violations
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Error())
+	}
+	return fmt.Sprintf("invalid %s: %s", e.Subject, strings.Join(messages, "; "))
+}
+
+// ValidateJSONSchema validates value against a JSON Schema. It supports the
+// keywords produced by Pydantic and TypedDict schemas: type, enum, const,
+// properties, required, additionalProperties, items, anyOf, oneOf, allOf,
+// local $ref, and the numeric, string and array bounds.
+func ValidateJSONSchema(jsonSchema map[string]any, value any) []FieldError {
+	v := &schemaValidator{root: jsonSchema, errors: []FieldError{}}
+	v.validate(jsonSchema, normalizeJSON(value), "", 0)
+	return v.errors
+}
+
+type schemaValidator struct {
+	root         map[string]any
+	skipRequired bool
+	errors       []FieldError
+}
+
+// maxSchemaDepth guards against self-referencing schemas.
+const maxSchemaDepth = 64
+
+func (v *schemaValidator) fail(path string, format string, args ...any) {
+	v.errors = append(v.errors, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *schemaValidator) validate(s map[string]any, value any, path string, depth int) {
+	if depth > maxSchemaDepth {
+		return
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		resolved, err := v.resolve(ref)
+		if err != nil {
+			v.fail(path, "%v", err)
+			return
+		}
+		v.validate(resolved, value, path, depth+1)
+	}
+
+	if t, ok := s["type"]; ok && !matchesType(t, value) {
+		v.fail(path, "expected %s, got %s", typeNames(t), jsonTypeOf(value))
+		return
+	}
+
+	if enum, ok := s["enum"].([]any); ok {
+		found := false
+		for _, candidate := range enum {
+			if reflect.DeepEqual(candidate, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.fail(path, "must be one of %s", renderValue(enum))
+		}
+	}
+	if constant, ok := s["const"]; ok && !reflect.DeepEqual(constant, value) {
+		v.fail(path, "must be %s", renderValue(constant))
+	}
+
+	for _, sub := range schemaList(s["allOf"]) {
+		v.validate(sub, value, path, depth+1)
+	}
+	if anyOf := schemaList(s["anyOf"]); len(anyOf) > 0 && v.countMatches(anyOf, value, depth) == 0 {
+		v.fail(path, "does not match any of the allowed schemas")
+	}
+	if oneOf := schemaList(s["oneOf"]); len(oneOf) > 0 {
+		if n := v.countMatches(oneOf, value, depth); n != 1 {
+			v.fail(path, "must match exactly one schema, matched %d", n)
+		}
+	}
+
+	switch val := value.(type) {
+	case map[string]any:
+		v.validateObject(s, val, path, depth)
+	case []any:
+		v.validateArray(s, val, path, depth)
+	case string:
+		v.validateString(s, val, path)
+	case float64:
+		v.validateNumber(s, val, path)
+	}
+}
+
+func (v *schemaValidator) validateObject(s map[string]any, value map[string]any, path string, depth int) {
+	properties, _ := s["properties"].(map[string]any)
+
+	if !v.skipRequired {
+		for _, name := range stringList(s["required"]) {
+			if _, ok := value[name]; !ok {
+				v.fail(path+"/"+escapePointer(name), "is required")
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(value))
+	for k := range value {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		childPath := path + "/" + escapePointer(k)
+		if propSchema, ok := properties[k].(map[string]any); ok {
+			v.validate(propSchema, value[k], childPath, depth+1)
+			continue
+		}
+		switch additional := s["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.fail(childPath, "is not an allowed property")
+			}
+		case map[string]any:
+			v.validate(additional, value[k], childPath, depth+1)
+		}
+	}
+}
+
+func (v *schemaValidator) validateArray(s map[string]any, value []any, path string, depth int) {
+	if n, ok := s["minItems"].(float64); ok && float64(len(value)) < n {
+		v.fail(path, "must have at least %d items", int(n))
+	}
+	if n, ok := s["maxItems"].(float64); ok && float64(len(value)) > n {
+		v.fail(path, "must have at most %d items", int(n))
+	}
+	if items, ok := s["items"].(map[string]any); ok {
+		for i, item := range value {
+			v.validate(items, item, path+"/"+strconv.Itoa(i), depth+1)
+		}
+	}
+}
+
+func (v *schemaValidator) validateString(s map[string]any, value string, path string) {
+	length := len([]rune(value))
+	if n, ok := s["minLength"].(float64); ok && float64(length) < n {
+		v.fail(path, "must be at least %d characters", int(n))
+	}
+	if n, ok := s["maxLength"].(float64); ok && float64(length) > n {
+		v.fail(path, "must be at most %d characters", int(n))
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err == nil && !re.MatchString(value) {
+			v.fail(path, "must match pattern %q", pattern)
+		}
+	}
+}
+
+func (v *schemaValidator) validateNumber(s map[string]any, value float64, path string) {
+	if n, ok := s["minimum"].(float64); ok && value < n {
+		v.fail(path, "must be >= %v", n)
+	}
+	if n, ok := s["maximum"].(float64); ok && value > n {
+		v.fail(path, "must be <= %v", n)
+	}
+	if n, ok := s["exclusiveMinimum"].(float64); ok && value <= n {
+		v.fail(path, "must be > %v", n)
+	}
+	if n, ok := s["exclusiveMaximum"].(float64); ok && value >= n {
+		v.fail(path, "must be < %v", n)
+	}
+}
+
+func (v *schemaValidator) countMatches(schemas []map[string]any, value any, depth int) int {
+	matches := 0
+	for _, sub := range schemas {
+		probe := &schemaValidator{root: v.root, skipRequired: v.skipRequired, errors: []FieldError{}}
+		probe.validate(sub, value, "", depth+1)
+		if len(probe.errors) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+func (v *schemaValidator) resolve(ref string) (map[string]any, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("unsupported schema reference %q", ref)
+	}
+
+	var current any = v.root
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unresolvable schema reference %q", ref)
+		}
+		current, ok = m[token]
+		if !ok {
+			return nil, fmt.Errorf("unresolvable schema reference %q", ref)
+		}
+	}
+
+	resolved, ok := current.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unresolvable schema reference %q", ref)
+	}
+	return resolved, nil
+}
+
+func matchesType(t any, value any) bool {
+	for _, name := range typeList(t) {
+		switch name {
+		case "null":
+			if value == nil {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "number":
+			if _, ok := value.(float64); ok {
+				return true
+			}
+		case "integer":
+			if n, ok := value.(float64); ok && n == math.Trunc(n) {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]any); ok {
+				return true
+			}
+		case "object":
+			if _, ok := value.(map[string]any); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jsonTypeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func typeList(t any) []string {
+	if name, ok := t.(string); ok {
+		return []string{name}
+	}
+	return stringList(t)
+}
+
+func typeNames(t any) string {
+	return strings.Join(typeList(t), " or ")
+}
+
+func stringList(value any) []string {
+	items, _ := value.([]any)
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func schemaList(value any) []map[string]any {
+	items, _ := value.([]any)
+	result := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(map[string]any); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// schemaMap converts a schema.Json schema into the plain map form used by the validator.
+func schemaMap(jsonSchema any) map[string]any {
+	data, err := json.Marshal(jsonSchema)
+	if err != nil {
+		return nil
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil
+	}
+	return m
+}
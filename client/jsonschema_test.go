@@ -0,0 +1,52 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateJSONSchema(t *testing.T) {
+	var jsonSchema map[string]any
+	err := json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {
+			"messages": {"type": "array", "items": {"$ref": "#/$defs/Message"}},
+			"temperature": {"anyOf": [{"type": "number", "maximum": 2}, {"type": "null"}]},
+			"mode": {"enum": ["fast", "slow"]}
+		},
+		"required": ["messages"],
+		"additionalProperties": false,
+		"$defs": {
+			"Message": {
+				"type": "object",
+				"properties": {"role": {"type": "string"}, "content": {"type": "string", "minLength": 1}},
+				"required": ["role", "content"]
+			}
+		}
+	}`), &jsonSchema)
+	assert.NoError(t, err)
+
+	assert.Empty(t, ValidateJSONSchema(jsonSchema, map[string]any{
+		"messages":    []any{map[string]any{"role": "user", "content": "hi"}},
+		"temperature": nil,
+	}))
+
+	errs := ValidateJSONSchema(jsonSchema, map[string]any{
+		"messages":    []any{map[string]any{"role": "user", "content": ""}, map[string]any{"role": 1, "content": "x"}},
+		"temperature": 3,
+		"mode":        "medium",
+		"extra":       true,
+	})
+	assert.Equal(t, []FieldError{
+		{Path: "/extra", Message: "is not an allowed property"},
+		{Path: "/messages/0/content", Message: "must be at least 1 characters"},
+		{Path: "/messages/1/role", Message: "expected string, got number"},
+		{Path: "/mode", Message: `must be one of ["fast","slow"]`},
+		{Path: "/temperature", Message: "does not match any of the allowed schemas"},
+	}, errs)
+
+	errs = ValidateJSONSchema(jsonSchema, map[string]any{})
+	assert.Equal(t, []FieldError{{Path: "/messages", Message: "is required"}}, errs)
+}
@@ -0,0 +1,248 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrLeaseHeld is returned by AcquireLease when another holder has the lease
+	ErrLeaseHeld = errors.New("lease is held by another holder")
+	// ErrLeaseLost is returned when a lease expired or was taken over
+	ErrLeaseLost = errors.New("lease lost")
+)
+
+// LeaseOptions holds the optional arguments of AcquireLease
+type LeaseOptions struct {
+	TTL     time.Duration      // How long the lease lasts without renewal; rounded up to whole minutes, the store's TTL unit; defaults to 1 minute
+	Holder  string             // Identifies the holder in the lease item; defaults to a random token
+	Settle  time.Duration      // Wait before reading the lease back after writing it, giving racing writers time to land; zero reads back immediately
+	Headers *map[string]string // Headers sent with every request
+}
+
+// Lease is a time limited lock on a store key, held by one worker at a time.
+//
+// The store has no compare-and-swap, so a lease is a best-effort lock: two
+// workers acquiring at the same moment can both write the lease item, and
+// the read-back only detects the loser if its write landed first. Expiry
+// relies on the store's TTL, which servers enforce by periodic sweeps, so a
+// crashed holder's lease may outlive its TTL by up to the sweep interval.
+// Work guarded by a lease should therefore be idempotent, or check the
+// lease's token (e.g. with Renew) before committing results.
+type Lease struct {
+	store     Store
+	namespace []string
+	key       string
+	token     string
+	ttl       time.Duration
+	headers   *map[string]string
+
+	mu       sync.Mutex
+	err      error
+	done     chan struct{}
+	stopKeep context.CancelFunc
+}
+
+// AcquireLease takes the lease stored under namespace and key, or returns
+// ErrLeaseHeld if another holder has it.
+func AcquireLease(ctx context.Context, store Store, namespace []string, key string, opts *LeaseOptions) (*Lease, error) {
+	if opts == nil {
+		opts = &LeaseOptions{}
+	}
+
+	ttl := max((opts.TTL + time.Minute - 1).Truncate(time.Minute), time.Minute)
+	token := opts.Holder
+	if token == "" {
+		var b [16]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			return nil, err
+		}
+		token = hex.EncodeToString(b[:])
+	}
+
+	lease := &Lease{
+		store:     store,
+		namespace: append([]string{}, namespace...),
+		key:       key,
+		token:     token,
+		ttl:       ttl,
+		headers:   opts.Headers,
+		done:      make(chan struct{}),
+	}
+
+	holder, err := lease.holder(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if holder != "" && holder != token {
+		return nil, fmt.Errorf("%w: '%s' held by '%s'", ErrLeaseHeld, lease.name(), holder)
+	}
+
+	value := map[string]any{"holder": token, "acquired_at": time.Now().UTC().Format(time.RFC3339Nano)}
+	if err := store.PutItem(ctx, namespace, key, value, nil, &ttl, opts.Headers); err != nil {
+		return nil, err
+	}
+
+	if opts.Settle > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(opts.Settle):
+		}
+	}
+
+	holder, err = lease.holder(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if holder != token {
+		return nil, fmt.Errorf("%w: '%s' held by '%s'", ErrLeaseHeld, lease.name(), holder)
+	}
+
+	return lease, nil
+}
+
+// Token returns the holder token written to the lease item.
+func (l *Lease) Token() string {
+	return l.token
+}
+
+// Renew extends the lease by its TTL from now, using the store's TTL refresh
+// on read. The lease item is first read without refreshing it, so that a
+// lease taken over by another holder is not extended on its behalf. It
+// returns ErrLeaseLost if the lease expired or another holder took it over.
+func (l *Lease) Renew(ctx context.Context) error {
+	if err := l.Err(); err != nil {
+		return err
+	}
+
+	holder, err := l.holder(ctx, false)
+	if err != nil {
+		return err
+	}
+	if holder != l.token {
+		return l.lose()
+	}
+
+	// The lease may still change hands between the two reads.
+	holder, err = l.holder(ctx, true)
+	if err != nil {
+		return err
+	}
+	if holder != l.token {
+		return l.lose()
+	}
+
+	return nil
+}
+
+// Release gives up the lease. Releasing a lease that was already lost is not
+// an error.
+func (l *Lease) Release(ctx context.Context) error {
+	l.mu.Lock()
+	if l.stopKeep != nil {
+		l.stopKeep()
+	}
+	l.mu.Unlock()
+
+	holder, err := l.holder(ctx, false)
+	if err != nil {
+		return err
+	}
+	if holder == l.token {
+		if err := l.store.DeleteItem(ctx, l.namespace, l.key, l.headers); err != nil {
+			return err
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err == nil {
+		l.err = fmt.Errorf("%w: '%s' released", ErrLeaseLost, l.name())
+		close(l.done)
+	}
+
+	return nil
+}
+
+// KeepAlive renews the lease every interval in the background until ctx is
+// cancelled, the lease is released, or a renewal finds it lost. An interval
+// of zero renews at a third of the TTL. Transient errors are retried at the
+// next interval.
+func (l *Lease) KeepAlive(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = l.ttl / 3
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	l.mu.Lock()
+	if l.stopKeep != nil {
+		l.stopKeep()
+	}
+	l.stopKeep = cancel
+	l.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-l.done:
+				return
+			case <-ticker.C:
+			}
+
+			if err := l.Renew(ctx); errors.Is(err, ErrLeaseLost) {
+				return
+			}
+		}
+	}()
+}
+
+// Done returns a channel closed when the lease is lost or released.
+func (l *Lease) Done() <-chan struct{} {
+	return l.done
+}
+
+// Err returns why the lease ended, or nil while it is held.
+func (l *Lease) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// holder returns the token in the lease item, or "" if there is none.
+func (l *Lease) holder(ctx context.Context, refresh bool) (string, error) {
+	item, err := l.store.GetItem(ctx, l.namespace, l.key, &refresh, l.headers)
+	if errors.Is(err, ErrItemNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	holder, _ := item.Value["holder"].(string)
+	return holder, nil
+}
+
+func (l *Lease) lose() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err == nil {
+		l.err = fmt.Errorf("%w: '%s'", ErrLeaseLost, l.name())
+		close(l.done)
+	}
+	return l.err
+}
+
+func (l *Lease) name() string {
+	return strings.Join(l.namespace, ".") + "/" + l.key
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLease(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	advance := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+	store := NewMemoryStore(&MemoryStoreOptions{Now: func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}})
+	namespace := []string{"locks", "threads"}
+
+	first, err := AcquireLease(ctx, store, namespace, "t1", &LeaseOptions{TTL: 90 * time.Second, Holder: "worker-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "worker-1", first.Token())
+
+	_, err = AcquireLease(ctx, store, namespace, "t1", &LeaseOptions{Holder: "worker-2"})
+	assert.ErrorIs(t, err, ErrLeaseHeld)
+
+	// The TTL is rounded up to two minutes and extended by each renewal.
+	advance(90 * time.Second)
+	assert.NoError(t, first.Renew(ctx))
+	advance(90 * time.Second)
+	assert.NoError(t, first.Renew(ctx))
+
+	advance(3 * time.Minute)
+	assert.ErrorIs(t, first.Renew(ctx), ErrLeaseLost)
+	<-first.Done()
+
+	second, err := AcquireLease(ctx, store, namespace, "t1", &LeaseOptions{Holder: "worker-2"})
+	assert.NoError(t, err)
+	assert.NoError(t, first.Release(ctx))
+	_, err = store.GetItem(ctx, namespace, "t1", nil, nil)
+	assert.NoError(t, err, "releasing a lost lease leaves the new holder's item alone")
+
+	second.KeepAlive(ctx, 10*time.Millisecond)
+	assert.NoError(t, second.Release(ctx))
+	assert.ErrorIs(t, second.Err(), ErrLeaseLost)
+	_, err = store.GetItem(ctx, namespace, "t1", nil, nil)
+	assert.ErrorIs(t, err, ErrItemNotFound)
+}
+
+func TestLeaseRenewDoesNotExtendTakenOverLease(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore(&MemoryStoreOptions{Now: func() time.Time { return now }})
+	namespace := []string{"locks", "threads"}
+
+	lease, err := AcquireLease(ctx, store, namespace, "t1", &LeaseOptions{Holder: "worker-1"})
+	assert.NoError(t, err)
+
+	// Another worker overwrites the lease item, e.g. after a racing acquire.
+	ttl := time.Minute
+	assert.NoError(t, store.PutItem(ctx, namespace, "t1", map[string]any{"holder": "worker-2"}, nil, &ttl, nil))
+	now = now.Add(30 * time.Second)
+
+	assert.ErrorIs(t, lease.Renew(ctx), ErrLeaseLost)
+	item, err := store.GetItem(ctx, namespace, "t1", new(bool), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "worker-2", item.Value["holder"])
+	assert.Equal(t, now.Add(30*time.Second), *item.ExpiresAt, "the new holder's lease is not refreshed")
+}
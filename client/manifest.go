@@ -0,0 +1,318 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/http"
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// AssistantManifest declares the desired set of assistants for an environment
+type AssistantManifest struct {
+	Assistants []AssistantSpec `json:"assistants"` // The assistants to manage
+}
+
+// AssistantSpec declares the desired state of a single assistant
+type AssistantSpec struct {
+	AssistantID string         `json:"assistant_id"`          // The ID of the assistant
+	GraphID     string         `json:"graph_id"`              // The ID of the graph
+	Name        string         `json:"name,omitempty"`        // The name of the assistant
+	Description *string        `json:"description,omitempty"` // The description of the assistant
+	Config      *schema.Config `json:"config,omitempty"`      // The assistant config
+	Metadata    schema.Json    `json:"metadata,omitempty"`    // Metadata the assistant must carry
+}
+
+// ParseManifest decodes a YAML or JSON assistant manifest and validates it.
+func ParseManifest(data []byte) (AssistantManifest, error) {
+	// YAML is a superset of JSON, so both formats go through the YAML decoder
+	// and are then re-encoded so that the JSON field tags apply.
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return AssistantManifest{}, fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return AssistantManifest{}, fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	var manifest AssistantManifest
+	if err := json.Unmarshal(encoded, &manifest); err != nil {
+		return AssistantManifest{}, fmt.Errorf("invalid manifest: %w", err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return AssistantManifest{}, err
+	}
+
+	return manifest, nil
+}
+
+// LoadManifest reads and parses a YAML or JSON assistant manifest.
+func LoadManifest(r io.Reader) (AssistantManifest, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return AssistantManifest{}, err
+	}
+	return ParseManifest(data)
+}
+
+// Validate checks that every assistant has an ID and a graph, and that IDs are unique.
+func (m AssistantManifest) Validate() error {
+	seen := map[string]bool{}
+	for i, spec := range m.Assistants {
+		if spec.AssistantID == "" {
+			return fmt.Errorf("manifest assistant %d: missing assistant_id", i)
+		}
+		if spec.GraphID == "" {
+			return fmt.Errorf("manifest assistant '%s': missing graph_id", spec.AssistantID)
+		}
+		if seen[spec.AssistantID] {
+			return fmt.Errorf("manifest assistant '%s': duplicate assistant_id", spec.AssistantID)
+		}
+		seen[spec.AssistantID] = true
+	}
+	return nil
+}
+
+// ReconcileAction is the change a plan makes to an assistant
+type ReconcileAction string
+
+const (
+	ReconcileActionCreate ReconcileAction = "create" // The assistant does not exist yet
+	ReconcileActionUpdate ReconcileAction = "update" // The assistant differs from its spec
+	ReconcileActionDelete ReconcileAction = "delete" // The assistant is not in the manifest and pruning is enabled
+	ReconcileActionNoop   ReconcileAction = "noop"   // The assistant matches its spec
+)
+
+// ReconcileOptions controls how a manifest is planned and applied
+type ReconcileOptions struct {
+	Prune    bool               // Delete assistants in scope that are not in the manifest
+	Selector *schema.Json       // Metadata filter limiting which existing assistants are pruned; assistants in the manifest are looked up by ID either way
+	Headers  *map[string]string // Headers sent with every request
+}
+
+// PlanStep is a single change in a ReconcilePlan
+type PlanStep struct {
+	Action      ReconcileAction   // The change to make
+	AssistantID string            // The assistant the change applies to
+	Spec        *AssistantSpec    // The desired state; nil for deletes
+	Current     *schema.Assistant // The existing assistant; nil for creates
+	Changes     []string          // The fields that differ, for updates
+}
+
+// ReconcilePlan is the set of changes needed to make the server match a manifest
+type ReconcilePlan struct {
+	Steps []PlanStep
+}
+
+// HasChanges reports whether applying the plan would change anything.
+func (p ReconcilePlan) HasChanges() bool {
+	for _, step := range p.Steps {
+		if step.Action != ReconcileActionNoop {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the plan one step per line, in the style of a terraform plan.
+func (p ReconcilePlan) String() string {
+	var b strings.Builder
+	counts := map[ReconcileAction]int{}
+	for _, step := range p.Steps {
+		counts[step.Action]++
+		switch step.Action {
+		case ReconcileActionCreate:
+			fmt.Fprintf(&b, "+ %s (graph %s)\n", step.AssistantID, step.Spec.GraphID)
+		case ReconcileActionUpdate:
+			fmt.Fprintf(&b, "~ %s: %s\n", step.AssistantID, strings.Join(step.Changes, ", "))
+		case ReconcileActionDelete:
+			fmt.Fprintf(&b, "- %s\n", step.AssistantID)
+		}
+	}
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to delete, %d unchanged.\n",
+		counts[ReconcileActionCreate], counts[ReconcileActionUpdate], counts[ReconcileActionDelete], counts[ReconcileActionNoop])
+	return b.String()
+}
+
+// Plan compares a manifest with the assistants on the server and returns the
+// changes needed to reconcile them. Nothing is modified.
+func (c *AssistantsClient) Plan(ctx context.Context, manifest AssistantManifest, opts ReconcileOptions) (ReconcilePlan, error) {
+	if err := manifest.Validate(); err != nil {
+		return ReconcilePlan{}, err
+	}
+
+	existing, err := c.searchAll(ctx, opts.Selector, opts.Headers)
+	if err != nil {
+		return ReconcilePlan{}, err
+	}
+
+	current := make(map[string]schema.Assistant, len(existing))
+	for _, assistant := range existing {
+		current[assistant.AssistantID] = assistant
+	}
+
+	plan := ReconcilePlan{Steps: []PlanStep{}}
+	managed := map[string]bool{}
+	for i := range manifest.Assistants {
+		spec := &manifest.Assistants[i]
+		managed[spec.AssistantID] = true
+
+		assistant, ok := current[spec.AssistantID]
+		if !ok && opts.Selector != nil {
+			// The assistant may exist outside the selector, in which case
+			// creating it with if_exists=do_nothing would silently do nothing.
+			assistant, ok, err = c.lookup(ctx, spec.AssistantID, opts.Headers)
+			if err != nil {
+				return ReconcilePlan{}, err
+			}
+		}
+		if !ok {
+			plan.Steps = append(plan.Steps, PlanStep{Action: ReconcileActionCreate, AssistantID: spec.AssistantID, Spec: spec})
+			continue
+		}
+
+		changes := specChanges(*spec, assistant)
+		action := ReconcileActionNoop
+		if len(changes) > 0 {
+			action = ReconcileActionUpdate
+		}
+		plan.Steps = append(plan.Steps, PlanStep{Action: action, AssistantID: spec.AssistantID, Spec: spec, Current: &assistant, Changes: changes})
+	}
+
+	if opts.Prune {
+		for _, assistant := range existing {
+			// Assistants created by the server for each graph are never pruned.
+			if managed[assistant.AssistantID] || assistant.Metadata["created_by"] == "system" {
+				continue
+			}
+			plan.Steps = append(plan.Steps, PlanStep{Action: ReconcileActionDelete, AssistantID: assistant.AssistantID, Current: &assistant})
+		}
+	}
+
+	return plan, nil
+}
+
+// Apply executes a plan returned by Plan. Creates use if_exists=do_nothing so
+// that re-applying a partially applied plan is safe. Apply stops at the first
+// failing step.
+func (c *AssistantsClient) Apply(ctx context.Context, plan ReconcilePlan, opts ReconcileOptions) error {
+	for _, step := range plan.Steps {
+		var err error
+		switch step.Action {
+		case ReconcileActionCreate:
+			spec := step.Spec
+			ifExists := schema.OnConflictBehaviorDoNothing
+			var name *string
+			if spec.Name != "" {
+				name = &spec.Name
+			}
+			var metadata *schema.Json
+			if spec.Metadata != nil {
+				metadata = &spec.Metadata
+			}
+			_, err = c.Create(ctx, &spec.GraphID, spec.Config, metadata, &spec.AssistantID, &ifExists, name, opts.Headers, spec.Description)
+		case ReconcileActionUpdate:
+			spec := step.Spec
+			var name *string
+			if spec.Name != "" {
+				name = &spec.Name
+			}
+			var metadata *schema.Json
+			if spec.Metadata != nil {
+				metadata = &spec.Metadata
+			}
+			_, err = c.Update(ctx, spec.AssistantID, &spec.GraphID, spec.Config, metadata, name, opts.Headers, spec.Description)
+		case ReconcileActionDelete:
+			err = c.Delete(ctx, step.AssistantID, opts.Headers)
+		}
+		if err != nil {
+			return fmt.Errorf("%s assistant '%s': %w", step.Action, step.AssistantID, err)
+		}
+	}
+	return nil
+}
+
+// Reconcile plans a manifest and applies the resulting plan.
+func (c *AssistantsClient) Reconcile(ctx context.Context, manifest AssistantManifest, opts ReconcileOptions) (ReconcilePlan, error) {
+	plan, err := c.Plan(ctx, manifest, opts)
+	if err != nil {
+		return ReconcilePlan{}, err
+	}
+	return plan, c.Apply(ctx, plan, opts)
+}
+
+// lookup fetches an assistant, reporting whether it exists.
+func (c *AssistantsClient) lookup(ctx context.Context, assistantID string, headers *map[string]string) (schema.Assistant, bool, error) {
+	assistant, err := c.Get(ctx, assistantID, headers)
+	var httpErr *http.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == nethttp.StatusNotFound {
+		return schema.Assistant{}, false, nil
+	}
+	if err != nil {
+		return schema.Assistant{}, false, err
+	}
+	return assistant, true, nil
+}
+
+func (c *AssistantsClient) searchAll(ctx context.Context, metadata *schema.Json, headers *map[string]string) ([]schema.Assistant, error) {
+	const pageSize = 100
+	sortBy := schema.AssistantSortByAssistantID
+	sortOrder := schema.SortOrderAsc
+
+	all := []schema.Assistant{}
+	for offset := 0; ; offset += pageSize {
+		limit := pageSize
+		pageOffset := offset
+		page, err := c.Search(ctx, metadata, nil, &limit, &pageOffset, &sortBy, &sortOrder, headers)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < pageSize {
+			return all, nil
+		}
+	}
+}
+
+// specChanges lists the fields of assistant that differ from spec. Metadata
+// only has to contain the spec's keys, since the server adds its own.
+func specChanges(spec AssistantSpec, assistant schema.Assistant) []string {
+	changes := []string{}
+	if spec.GraphID != assistant.GraphID {
+		changes = append(changes, "graph_id")
+	}
+	if spec.Name != "" && spec.Name != assistant.Name {
+		changes = append(changes, "name")
+	}
+	if spec.Description != nil && (assistant.Description == nil || *spec.Description != *assistant.Description) {
+		changes = append(changes, "description")
+	}
+	if spec.Config != nil && !reflect.DeepEqual(normalizeJSON(*spec.Config), normalizeJSON(assistant.Config)) {
+		changes = append(changes, "config")
+	}
+	if len(spec.Metadata) > 0 {
+		keys := make([]string, 0, len(spec.Metadata))
+		for k := range spec.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if !reflect.DeepEqual(normalizeJSON(spec.Metadata[k]), normalizeJSON(assistant.Metadata[k])) {
+				changes = append(changes, "metadata")
+				break
+			}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/http"
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseManifest(t *testing.T) {
+	manifest, err := ParseManifest([]byte(`
+assistants:
+  - assistant_id: support-bot
+    graph_id: agent
+    name: Support
+    config:
+      recursion_limit: 10
+      configurable:
+        model: gpt-4o
+    metadata:
+      team: support
+`))
+
+	assert.NoError(t, err)
+	assert.Len(t, manifest.Assistants, 1)
+	spec := manifest.Assistants[0]
+	assert.Equal(t, "support-bot", spec.AssistantID)
+	assert.Equal(t, 10, spec.Config.RecursionLimit)
+	assert.Equal(t, "gpt-4o", spec.Config.Configurable["model"])
+
+	current := schema.Assistant{
+		AssistantBase: schema.AssistantBase{
+			AssistantID: "support-bot",
+			GraphID:     "agent",
+			Config:      schema.Config{RecursionLimit: 10, Configurable: map[string]any{"model": "gpt-4o"}},
+			Metadata:    schema.Json{"team": "support", "created_by": "user"},
+		},
+		Name: "Support",
+	}
+	assert.Empty(t, specChanges(spec, current))
+
+	current.Config.RecursionLimit = 25
+	current.Name = "Old"
+	assert.Equal(t, []string{"name", "config"}, specChanges(spec, current))
+}
+
+func TestParseManifestRejectsDuplicates(t *testing.T) {
+	_, err := ParseManifest([]byte(`{"assistants": [
+		{"assistant_id": "a", "graph_id": "g"},
+		{"assistant_id": "a", "graph_id": "g"}
+	]}`))
+
+	assert.ErrorContains(t, err, "duplicate assistant_id")
+}
+
+func TestPlanWithSelectorFindsAssistantsOutsideIt(t *testing.T) {
+	assistants := NewAssistantsClient(http.NewHttpClient("http://assistants.test", nil, 5*time.Second, roundTripFunc(func(req *nethttp.Request) (int, string) {
+		switch req.Method + " " + req.URL.Path {
+		case "POST /assistants/search":
+			return 200, `[{"assistant_id":"labelled","graph_id":"agent","metadata":{"team":"support"}}]`
+		case "GET /assistants/unlabelled":
+			return 200, `{"assistant_id":"unlabelled","graph_id":"agent","metadata":{}}`
+		}
+		return 404, `{"detail":"Assistant not found"}`
+	})))
+
+	manifest := AssistantManifest{Assistants: []AssistantSpec{
+		{AssistantID: "labelled", GraphID: "agent", Metadata: schema.Json{"team": "support"}},
+		{AssistantID: "unlabelled", GraphID: "agent", Metadata: schema.Json{"team": "support"}},
+		{AssistantID: "new", GraphID: "agent"},
+	}}
+	plan, err := assistants.Plan(context.Background(), manifest, ReconcileOptions{Selector: &schema.Json{"team": "support"}})
+	assert.NoError(t, err)
+
+	actions := map[string]ReconcileAction{}
+	for _, step := range plan.Steps {
+		actions[step.AssistantID] = step.Action
+	}
+	assert.Equal(t, map[string]ReconcileAction{
+		"labelled":   ReconcileActionNoop,
+		"unlabelled": ReconcileActionUpdate,
+		"new":        ReconcileActionCreate,
+	}, actions)
+}
+
+func TestReconcileAppliesPlan(t *testing.T) {
+	for _, prune := range []bool{false, true} {
+		requests := []string{}
+		bodies := map[string]map[string]any{}
+		assistants := NewAssistantsClient(http.NewHttpClient("http://assistants.test", nil, 5*time.Second, roundTripFunc(func(req *nethttp.Request) (int, string) {
+			route := req.Method + " " + req.URL.Path
+			if route == "POST /assistants/search" {
+				return 200, `[
+					{"assistant_id":"changed","graph_id":"agent","name":"old","metadata":{}},
+					{"assistant_id":"same","graph_id":"agent","metadata":{}},
+					{"assistant_id":"stale","graph_id":"agent","metadata":{}},
+					{"assistant_id":"system","graph_id":"agent","metadata":{"created_by":"system"}}
+				]`
+			}
+			requests = append(requests, route)
+			if req.Method == nethttp.MethodDelete {
+				return 204, ``
+			}
+			data, _ := io.ReadAll(req.Body)
+			var body map[string]any
+			json.Unmarshal(data, &body)
+			bodies[route] = body
+			return 200, `{"assistant_id":"a","graph_id":"agent"}`
+		})))
+
+		manifest := AssistantManifest{Assistants: []AssistantSpec{
+			{AssistantID: "changed", GraphID: "agent", Name: "new"},
+			{AssistantID: "same", GraphID: "agent"},
+			{AssistantID: "created", GraphID: "agent"},
+		}}
+		plan, err := assistants.Reconcile(context.Background(), manifest, ReconcileOptions{Prune: prune})
+		assert.NoError(t, err)
+		assert.True(t, plan.HasChanges())
+
+		expected := []string{"PATCH /assistants/changed", "POST /assistants"}
+		if prune {
+			// The system assistant is never pruned.
+			expected = append(expected, "DELETE /assistants/stale")
+		}
+		assert.Equal(t, expected, requests, "prune=%v", prune)
+		assert.Equal(t, "new", bodies["PATCH /assistants/changed"]["name"])
+		assert.Equal(t, "created", bodies["POST /assistants"]["assistant_id"])
+		assert.Equal(t, "do_nothing", bodies["POST /assistants"]["if_exists"])
+	}
+}
+
+func TestApplyStopsAtFirstFailure(t *testing.T) {
+	requests := []string{}
+	assistants := NewAssistantsClient(http.NewHttpClient("http://assistants.test", nil, 5*time.Second, roundTripFunc(func(req *nethttp.Request) (int, string) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		return 500, `{"detail":"boom"}`
+	})))
+
+	spec := AssistantSpec{AssistantID: "a1", GraphID: "agent"}
+	plan := ReconcilePlan{Steps: []PlanStep{
+		{Action: ReconcileActionCreate, AssistantID: "a1", Spec: &spec},
+		{Action: ReconcileActionDelete, AssistantID: "a2"},
+	}}
+	err := assistants.Apply(context.Background(), plan, ReconcileOptions{})
+	assert.ErrorContains(t, err, "create assistant 'a1'")
+	assert.Equal(t, []string{"POST /assistants"}, requests)
+}
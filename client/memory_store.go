@@ -0,0 +1,300 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/KhanhD1nh/langgraph-sdk-go/store"
+)
+
+// SimilarityFunc scores how well an item value matches a search query.
+// Higher scores rank first; items scoring zero or less are left out.
+type SimilarityFunc func(query string, value map[string]any) float64
+
+// MemoryStoreOptions configures a MemoryStore
+type MemoryStoreOptions struct {
+	Similarity SimilarityFunc   // Scores items for queries; defaults to KeywordSimilarity
+	Now        func() time.Time // Clock used for timestamps and TTL expiry; defaults to time.Now
+}
+
+// MemoryStore is an in-process Store for tests and offline tools. It mirrors
+// the server's behaviour for namespaces, filters, pagination and TTLs, and
+// scores queries with a pluggable similarity function instead of
+// embeddings. Headers and index configurations are accepted and ignored.
+type MemoryStore struct {
+	similarity SimilarityFunc
+	now        func() time.Time
+
+	mu    sync.Mutex
+	items map[string]*memoryItem
+}
+
+type memoryItem struct {
+	item      schema.Item
+	ttl       time.Duration
+	expiresAt time.Time
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore creates an empty MemoryStore. opts may be nil.
+func NewMemoryStore(opts *MemoryStoreOptions) *MemoryStore {
+	s := &MemoryStore{
+		similarity: KeywordSimilarity,
+		now:        time.Now,
+		items:      map[string]*memoryItem{},
+	}
+	if opts != nil && opts.Similarity != nil {
+		s.similarity = opts.Similarity
+	}
+	if opts != nil && opts.Now != nil {
+		s.now = opts.Now
+	}
+	return s
+}
+
+func (s *MemoryStore) PutItem(ctx context.Context, namespace []string, key string, value map[string]any, index *any, ttl *time.Duration, headers *map[string]string) error {
+	if err := validateNamespace(namespace); err != nil {
+		return err
+	}
+	if len(namespace) == 0 {
+		return fmt.Errorf("namespace cannot be empty")
+	}
+	if ttl != nil {
+		if err := ValidateTTL(*ttl); err != nil {
+			return err
+		}
+	}
+	copied, ok := normalizeJSON(value).(map[string]any)
+	if !ok {
+		copied = map[string]any{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now().UTC()
+	id := memoryItemID(namespace, key)
+	entry := &memoryItem{item: schema.Item{
+		Namespace: append([]string{}, namespace...),
+		Key:       key,
+		Value:     copied,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}}
+	if existing, ok := s.items[id]; ok && !existing.expired(now) {
+		entry.item.CreatedAt = existing.item.CreatedAt
+	}
+	if ttl != nil {
+		entry.ttl = *ttl
+		entry.expiresAt = now.Add(entry.ttl)
+	}
+	s.items[id] = entry
+
+	return nil
+}
+
+// GetItem returns a copy of the item. Its TTL is refreshed unless refreshTtl
+// is false, as on the server.
+func (s *MemoryStore) GetItem(ctx context.Context, namespace []string, key string, refreshTtl *bool, headers *map[string]string) (*schema.Item, error) {
+	if err := validateNamespace(namespace); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	entry, ok := s.items[memoryItemID(namespace, key)]
+	if !ok || entry.expired(now) {
+		return nil, fmt.Errorf("%w: '%s' in namespace '%s'", ErrItemNotFound, key, strings.Join(namespace, "."))
+	}
+	entry.refresh(now, refreshTtl)
+
+	item := entry.copy()
+	return &item, nil
+}
+
+// Touch refreshes the TTL of an item, returning ErrItemNotFound if it does
+// not exist.
+func (s *MemoryStore) Touch(ctx context.Context, namespace []string, key string, headers *map[string]string) error {
+	refresh := true
+	_, err := s.GetItem(ctx, namespace, key, &refresh, headers)
+	return err
+}
+
+func (s *MemoryStore) DeleteItem(ctx context.Context, namespace []string, key string, headers *map[string]string) error {
+	if err := validateNamespace(namespace); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.items, memoryItemID(namespace, key))
+
+	return nil
+}
+
+// SearchItems returns the items beneath the namespace prefix that match
+// filter. Without a query, items are ordered by most recent update; with a
+// query, by descending similarity score. Like the server, it rejects the
+// evaluator-only $in and $exists operators, as store.Filter.Compile does.
+func (s *MemoryStore) SearchItems(ctx context.Context, namespace []string, filter *map[string]any, limit *int, offset *int, query *string, refreshTtl *bool, headers *map[string]string) (schema.SearchItemsResponse, error) {
+	var match store.Filter
+	if filter != nil {
+		var err error
+		if match, err = store.ParseFilter(*filter); err != nil {
+			return schema.SearchItemsResponse{}, err
+		}
+		if _, err := match.Compile(); err != nil {
+			return schema.SearchItemsResponse{}, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	type candidate struct {
+		entry *memoryItem
+		score *float64
+	}
+	candidates := []candidate{}
+	for _, entry := range s.items {
+		if entry.expired(now) || !isNamespacePrefix(namespace, entry.item.Namespace) || !match.Match(entry.item.Value) {
+			continue
+		}
+		c := candidate{entry: entry}
+		if query != nil && *query != "" {
+			score := s.similarity(*query, entry.item.Value)
+			if score <= 0 {
+				continue
+			}
+			c.score = &score
+		}
+		candidates = append(candidates, c)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.score != nil && b.score != nil && *a.score != *b.score {
+			return *a.score > *b.score
+		}
+		if !a.entry.item.UpdatedAt.Equal(b.entry.item.UpdatedAt) {
+			return a.entry.item.UpdatedAt.After(b.entry.item.UpdatedAt)
+		}
+		return memoryItemID(a.entry.item.Namespace, a.entry.item.Key) < memoryItemID(b.entry.item.Namespace, b.entry.item.Key)
+	})
+
+	start, end := page(len(candidates), limit, offset, 10)
+	response := schema.SearchItemsResponse{Items: []schema.SearchItem{}}
+	for _, c := range candidates[start:end] {
+		c.entry.refresh(now, refreshTtl)
+		response.Items = append(response.Items, schema.SearchItem{Item: c.entry.copy(), Score: c.score})
+	}
+
+	return response, nil
+}
+
+// ListNamespaces lists the distinct namespaces holding items, sorted, with
+// the same prefix, suffix and max depth semantics as the server.
+func (s *MemoryStore) ListNamespaces(ctx context.Context, prefix *[]string, suffix *[]string, maxDepth *int, limit *int, offset *int, headers *map[string]string) ([]schema.ListNamespaceResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	seen := map[string]bool{}
+	namespaces := [][]string{}
+	for _, entry := range s.items {
+		namespace := entry.item.Namespace
+		if entry.expired(now) {
+			continue
+		}
+		if prefix != nil && !isNamespacePrefix(*prefix, namespace) {
+			continue
+		}
+		if suffix != nil && (len(*suffix) > len(namespace) || !slices.Equal(namespace[len(namespace)-len(*suffix):], *suffix)) {
+			continue
+		}
+		if maxDepth != nil && *maxDepth > 0 && len(namespace) > *maxDepth {
+			namespace = namespace[:*maxDepth]
+		}
+		id := strings.Join(namespace, ".")
+		if !seen[id] {
+			seen[id] = true
+			namespaces = append(namespaces, append([]string{}, namespace...))
+		}
+	}
+
+	sort.Slice(namespaces, func(i, j int) bool { return slices.Compare(namespaces[i], namespaces[j]) < 0 })
+
+	start, end := page(len(namespaces), limit, offset, 100)
+	return []schema.ListNamespaceResponse{{Namespaces: namespaces[start:end]}}, nil
+}
+
+// KeywordSimilarity scores a value by the fraction of query words that occur
+// in its JSON encoding, ignoring case.
+func KeywordSimilarity(query string, value map[string]any) float64 {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return 0
+	}
+
+	data, _ := json.Marshal(value)
+	text := strings.ToLower(string(data))
+	found := 0
+	for _, word := range words {
+		if strings.Contains(text, word) {
+			found++
+		}
+	}
+	return float64(found) / float64(len(words))
+}
+
+func (e *memoryItem) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+func (e *memoryItem) refresh(now time.Time, refreshTtl *bool) {
+	if e.ttl > 0 && (refreshTtl == nil || *refreshTtl) {
+		e.expiresAt = now.Add(e.ttl)
+	}
+}
+
+func (e *memoryItem) copy() schema.Item {
+	item := e.item
+	item.Namespace = append([]string{}, e.item.Namespace...)
+	item.Value, _ = normalizeJSON(e.item.Value).(map[string]any)
+	if !e.expiresAt.IsZero() {
+		expiresAt := e.expiresAt.UTC()
+		item.ExpiresAt = &expiresAt
+	}
+	return item
+}
+
+func memoryItemID(namespace []string, key string) string {
+	return strings.Join(namespace, ".") + "\x00" + key
+}
+
+// page returns the bounds of a limit/offset page of n results.
+func page(n int, limit *int, offset *int, defaultLimit int) (int, int) {
+	l, o := defaultLimit, 0
+	if limit != nil && *limit > 0 {
+		l = *limit
+	}
+	if offset != nil && *offset > 0 {
+		o = *offset
+	}
+	start := min(o, n)
+	return start, min(start+l, n)
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/KhanhD1nh/langgraph-sdk-go/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStoreTTL(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewMemoryStore(&MemoryStoreOptions{Now: func() time.Time { return now }})
+
+	ttl := 10 * time.Minute
+	assert.NoError(t, s.PutItem(ctx, []string{"users", "u1"}, "session", map[string]any{"id": 1}, nil, &ttl, nil))
+
+	now = now.Add(8 * time.Minute)
+	noRefresh := false
+	_, err := s.GetItem(ctx, []string{"users", "u1"}, "session", &noRefresh, nil)
+	assert.NoError(t, err)
+
+	now = now.Add(3 * time.Minute)
+	_, err = s.GetItem(ctx, []string{"users", "u1"}, "session", nil, nil)
+	assert.ErrorIs(t, err, ErrItemNotFound)
+
+	assert.NoError(t, s.PutItem(ctx, []string{"users", "u1"}, "session", map[string]any{"id": 2}, nil, &ttl, nil))
+	now = now.Add(8 * time.Minute)
+	_, err = s.GetItem(ctx, []string{"users", "u1"}, "session", nil, nil)
+	assert.NoError(t, err)
+	now = now.Add(8 * time.Minute)
+	item, err := s.GetItem(ctx, []string{"users", "u1"}, "session", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), item.Value["id"])
+}
+
+func TestMemoryStoreSearch(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewMemoryStore(&MemoryStoreOptions{Now: func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}})
+
+	put := func(namespace []string, key string, value map[string]any) {
+		assert.NoError(t, s.PutItem(ctx, namespace, key, value, nil, nil, nil))
+	}
+	put([]string{"users", "u1", "memories"}, "m1", map[string]any{"text": "likes green tea", "score": 0.9})
+	put([]string{"users", "u1", "memories"}, "m2", map[string]any{"text": "works in billing", "score": 0.4})
+	put([]string{"users", "u2", "memories"}, "m3", map[string]any{"text": "prefers tea over coffee", "score": 0.7})
+	put([]string{"users", "u2", "settings"}, "theme", map[string]any{"value": "dark"})
+
+	filter := store.Gt("score", 0.5).Map()
+	resp, err := s.SearchItems(ctx, []string{"users"}, &filter, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"m3", "m1"}, searchKeys(resp))
+
+	query := "green tea"
+	resp, err = s.SearchItems(ctx, []string{"users"}, nil, nil, nil, &query, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"m1", "m3"}, searchKeys(resp))
+	assert.Equal(t, 1.0, *resp.Items[0].Score)
+
+	limit, offset := 2, 1
+	resp, err = s.SearchItems(ctx, []string{"users", "u1"}, nil, &limit, &offset, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"m1"}, searchKeys(resp))
+
+	maxDepth := 2
+	suffix := []string{"memories"}
+	namespaces, err := s.ListNamespaces(ctx, nil, &suffix, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"users", "u1", "memories"}, {"users", "u2", "memories"}}, namespaces[0].Namespaces)
+	namespaces, err = s.ListNamespaces(ctx, &[]string{"users"}, nil, &maxDepth, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"users", "u1"}, {"users", "u2"}}, namespaces[0].Namespaces)
+
+	memories := NewTypedStore[testMemory](s, []string{"users", "u3"})
+	assert.NoError(t, memories.Put(ctx, "m4", testMemory{Topic: "travel"}, nil))
+	value, meta, err := memories.Get(ctx, "m4")
+	assert.NoError(t, err)
+	assert.Equal(t, "travel", value.Topic)
+	assert.Equal(t, []string{"users", "u3"}, meta.Namespace)
+
+	// Evaluator-only operators are rejected as the server does, except $in
+	// with a single value.
+	for _, filter := range []store.Filter{store.In("text", "a", "b"), store.Exists("score"), store.Missing("score")} {
+		m := filter.Map()
+		_, err := s.SearchItems(ctx, []string{"users"}, &m, nil, nil, nil, nil, nil)
+		assert.ErrorContains(t, err, "not supported by the store search endpoint", filter.String())
+	}
+	single := store.In("value", "dark").Map()
+	resp, err = s.SearchItems(ctx, []string{"users"}, &single, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"theme"}, searchKeys(resp))
+}
+
+func searchKeys(resp schema.SearchItemsResponse) []string {
+	keys := []string{}
+	for _, item := range resp.Items {
+		keys = append(keys, item.Key)
+	}
+	return keys
+}
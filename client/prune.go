@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// PruneOptions selects the threads removed by ThreadsClient.Prune
+type PruneOptions struct {
+	OlderThan   time.Duration        // Only threads not updated within this duration; zero matches any age
+	Status      *schema.ThreadStatus // Only threads with this status
+	Metadata    *schema.Json         // Only threads whose metadata contains these key/value pairs
+	MaxThreads  int                  // Maximum number of threads to delete; zero means no limit
+	PageSize    int                  // Number of threads fetched per search request; defaults to 100
+	Concurrency int                  // Maximum number of concurrent deletes; defaults to 4
+	RateLimit   float64              // Maximum deletes per second; zero means unlimited
+	DryRun      bool                 // Report the matching threads without deleting them
+	OnProgress  func(PruneProgress)  // Called after each thread is processed
+	Headers     *map[string]string   // Headers sent with every request
+}
+
+// PruneProgress reports the outcome of processing a single thread
+type PruneProgress struct {
+	ThreadID  string // The thread that was processed
+	Err       error  // The delete error, if any
+	Processed int    // Number of threads processed so far
+	Total     int    // Number of threads selected for deletion
+}
+
+// PruneResult summarises a ThreadsClient.Prune call
+type PruneResult struct {
+	Matched []string         // IDs of threads matching the options, oldest first
+	Deleted []string         // IDs of threads that were deleted
+	Failed  map[string]error // Delete errors keyed by thread ID
+	DryRun  bool             // Whether the call was a dry run
+}
+
+// Prune deletes threads selected by age, status and metadata. Matching threads
+// are collected first, oldest updated first, and then deleted with bounded
+// concurrency. A cancelled context stops scheduling further deletes.
+func (c *ThreadsClient) Prune(ctx context.Context, opts PruneOptions) (PruneResult, error) {
+	matched, err := c.selectPruneCandidates(ctx, opts)
+	result := PruneResult{
+		Matched: matched,
+		Deleted: []string{},
+		Failed:  map[string]error{},
+		DryRun:  opts.DryRun,
+	}
+	if err != nil {
+		return result, err
+	}
+
+	if opts.DryRun {
+		for i, threadID := range matched {
+			if opts.OnProgress != nil {
+				opts.OnProgress(PruneProgress{ThreadID: threadID, Processed: i + 1, Total: len(matched)})
+			}
+		}
+		return result, nil
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	var throttle <-chan time.Time
+	if opts.RateLimit > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / opts.RateLimit))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		processed int
+	)
+	sem := make(chan struct{}, concurrency)
+
+schedule:
+	for i, threadID := range matched {
+		if throttle != nil && i > 0 {
+			select {
+			case <-ctx.Done():
+				break schedule
+			case <-throttle:
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			break schedule
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(threadID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := c.Delete(ctx, threadID, opts.Headers)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Failed[threadID] = err
+			} else {
+				result.Deleted = append(result.Deleted, threadID)
+			}
+			processed++
+			if opts.OnProgress != nil {
+				opts.OnProgress(PruneProgress{ThreadID: threadID, Err: err, Processed: processed, Total: len(matched)})
+			}
+		}(threadID)
+	}
+	wg.Wait()
+
+	return result, ctx.Err()
+}
+
+func (c *ThreadsClient) selectPruneCandidates(ctx context.Context, opts PruneOptions) ([]string, error) {
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+
+	var cutoff time.Time
+	if opts.OlderThan > 0 {
+		cutoff = time.Now().Add(-opts.OlderThan)
+	}
+
+	sortBy := schema.ThreadSortByUpdatedAt
+	sortOrder := schema.SortOrderAsc
+	matched := []string{}
+
+	for offset := 0; ; offset += pageSize {
+		limit := pageSize
+		pageOffset := offset
+		threads, err := c.Search(ctx, opts.Metadata, nil, opts.Status, &limit, &pageOffset, &sortBy, &sortOrder, opts.Headers)
+		if err != nil {
+			return matched, err
+		}
+
+		for _, thread := range threads {
+			// Threads are sorted by update time, so the first recent one ends the scan.
+			if !cutoff.IsZero() && !thread.UpdatedAt.Before(cutoff) {
+				return matched, nil
+			}
+			matched = append(matched, thread.ThreadID)
+			if opts.MaxThreads > 0 && len(matched) >= opts.MaxThreads {
+				return matched, nil
+			}
+		}
+
+		if len(threads) < pageSize {
+			return matched, nil
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	nethttp "net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/http"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePruneServer serves thread searches sorted by update time, oldest first,
+// and records deletes.
+type fakePruneServer struct {
+	mu        sync.Mutex
+	updated   []time.Time // Update time of thread "t<i>"
+	fail      map[string]bool
+	delay     time.Duration
+	searches  []int // Offsets of the search requests
+	deletes   []string
+	deletedAt []time.Time
+	inFlight  int
+	maxFlight int
+}
+
+func newFakePruneServer(n int) *fakePruneServer {
+	now := time.Now()
+	updated := make([]time.Time, n)
+	for i := range updated {
+		// t0 is the oldest, updated n days ago.
+		updated[i] = now.Add(-time.Duration(n-i) * 24 * time.Hour)
+	}
+	return &fakePruneServer{updated: updated, fail: map[string]bool{}}
+}
+
+func (f *fakePruneServer) serve(req *nethttp.Request) (int, string) {
+	if req.Method == nethttp.MethodDelete {
+		threadID := strings.TrimPrefix(req.URL.Path, "/threads/")
+		f.mu.Lock()
+		f.deletes = append(f.deletes, threadID)
+		f.deletedAt = append(f.deletedAt, time.Now())
+		f.inFlight++
+		f.maxFlight = max(f.maxFlight, f.inFlight)
+		f.mu.Unlock()
+
+		time.Sleep(f.delay)
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.inFlight--
+		if f.fail[threadID] {
+			return 500, `{"detail":"delete failed"}`
+		}
+		return 204, ``
+	}
+
+	var body struct {
+		Limit  int `json:"limit"`
+		Offset int `json:"offset"`
+	}
+	json.NewDecoder(req.Body).Decode(&body)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.searches = append(f.searches, body.Offset)
+	threads := []string{}
+	for i := body.Offset; i < min(body.Offset+body.Limit, len(f.updated)); i++ {
+		threads = append(threads, fmt.Sprintf(`{"thread_id":"t%d","updated_at":%q}`, i, f.updated[i].Format(time.RFC3339Nano)))
+	}
+	return 200, "[" + strings.Join(threads, ",") + "]"
+}
+
+func newTestPruneClient(server *fakePruneServer) *ThreadsClient {
+	return NewThreadsClient(http.NewHttpClient("http://threads.test", nil, 5*time.Second, roundTripFunc(server.serve)))
+}
+
+func TestPruneSelection(t *testing.T) {
+	server := newFakePruneServer(10)
+	threads := newTestPruneClient(server)
+
+	// Threads t0-t6 are older than three and a half days; pages of 3 are
+	// fetched until the first recent thread.
+	result, err := threads.Prune(context.Background(), PruneOptions{OlderThan: 84 * time.Hour, PageSize: 3, DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"t0", "t1", "t2", "t3", "t4", "t5", "t6"}, result.Matched)
+	assert.Equal(t, []int{0, 3, 6}, server.searches)
+	assert.True(t, result.DryRun)
+	assert.Empty(t, result.Deleted)
+	assert.Empty(t, server.deletes)
+
+	server.searches = nil
+	result, err = threads.Prune(context.Background(), PruneOptions{MaxThreads: 4, PageSize: 3, DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"t0", "t1", "t2", "t3"}, result.Matched)
+	assert.Equal(t, []int{0, 3}, server.searches)
+
+	// Without a cutoff or limit, paging stops at the first short page.
+	server.searches = nil
+	result, err = threads.Prune(context.Background(), PruneOptions{PageSize: 5, DryRun: true})
+	assert.NoError(t, err)
+	assert.Len(t, result.Matched, 10)
+	assert.Equal(t, []int{0, 5, 10}, server.searches)
+}
+
+func TestPruneDryRunProgress(t *testing.T) {
+	server := newFakePruneServer(3)
+	threads := newTestPruneClient(server)
+
+	progress := []PruneProgress{}
+	_, err := threads.Prune(context.Background(), PruneOptions{DryRun: true, OnProgress: func(p PruneProgress) {
+		progress = append(progress, p)
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, []PruneProgress{
+		{ThreadID: "t0", Processed: 1, Total: 3},
+		{ThreadID: "t1", Processed: 2, Total: 3},
+		{ThreadID: "t2", Processed: 3, Total: 3},
+	}, progress)
+	assert.Empty(t, server.deletes)
+}
+
+func TestPruneDeletes(t *testing.T) {
+	server := newFakePruneServer(8)
+	server.fail["t2"] = true
+	server.fail["t5"] = true
+	server.delay = 20 * time.Millisecond
+	threads := newTestPruneClient(server)
+
+	var mu sync.Mutex
+	processed := []int{}
+	failures := 0
+	result, err := threads.Prune(context.Background(), PruneOptions{Concurrency: 2, OnProgress: func(p PruneProgress) {
+		mu.Lock()
+		defer mu.Unlock()
+		processed = append(processed, p.Processed)
+		assert.Equal(t, 8, p.Total)
+		if p.Err != nil {
+			failures++
+		}
+	}})
+	assert.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"t0", "t1", "t3", "t4", "t6", "t7"}, result.Deleted)
+	assert.Len(t, result.Failed, 2)
+	assert.Contains(t, result.Failed, "t2")
+	assert.Contains(t, result.Failed, "t5")
+	assert.Equal(t, 2, failures)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, processed)
+	assert.Len(t, server.deletes, 8)
+	assert.Equal(t, 2, server.maxFlight)
+}
+
+func TestPruneRateLimit(t *testing.T) {
+	server := newFakePruneServer(5)
+	threads := newTestPruneClient(server)
+
+	result, err := threads.Prune(context.Background(), PruneOptions{RateLimit: 50, Concurrency: 5})
+	assert.NoError(t, err)
+	assert.Len(t, result.Deleted, 5)
+
+	// Five deletes at 50 per second are spread over at least 80ms.
+	assert.GreaterOrEqual(t, server.deletedAt[4].Sub(server.deletedAt[0]), 70*time.Millisecond)
+	for i := 1; i < len(server.deletedAt); i++ {
+		assert.GreaterOrEqual(t, server.deletedAt[i].Sub(server.deletedAt[i-1]), 10*time.Millisecond)
+	}
+}
+
+func TestPruneCancel(t *testing.T) {
+	server := newFakePruneServer(10)
+	threads := newTestPruneClient(server)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	result, err := threads.Prune(ctx, PruneOptions{Concurrency: 1, RateLimit: 100, OnProgress: func(p PruneProgress) {
+		if p.Processed == 2 {
+			cancel()
+		}
+	}})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, result.Matched, 10)
+	assert.Len(t, result.Deleted, 2)
+	assert.Len(t, server.deletes, 2)
+}
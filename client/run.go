@@ -11,14 +11,66 @@ import (
 )
 
 type RunsClient struct {
-	http *http.HttpClient
+	http       *http.HttpClient
+	validators []RunValidator
 }
 
 func NewRunsClient(httpClient *http.HttpClient) *RunsClient {
 	return &RunsClient{http: httpClient}
 }
 
+// AddValidator registers a validator that checks every run passed to Create,
+// Stream and Wait before the request is sent.
+func (c *RunsClient) AddValidator(validator RunValidator) {
+	c.validators = append(c.validators, validator)
+}
+
+func (c *RunsClient) validateRun(ctx context.Context, threadID string, assistantID string, input *map[string]any, config *schema.Config, interruptBefore *[]string, interruptAfter *[]string, headers *map[string]string) error {
+	if len(c.validators) == 0 {
+		return nil
+	}
+
+	run := schema.RunCreate{
+		AssistantID: assistantID,
+		Config:      config,
+	}
+	if threadID != "" {
+		run.ThreadID = &threadID
+	}
+	if input != nil {
+		run.Input = *input
+	}
+	if interruptBefore != nil {
+		run.InterruptBefore = *interruptBefore
+	}
+	if interruptAfter != nil {
+		run.InterruptAfter = *interruptAfter
+	}
+
+	for _, validator := range c.validators {
+		if err := validator.ValidateRun(ctx, run, headers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// errorStream returns a closed stream holding a single error event, matching
+// how the server reports errors inside a stream.
+func errorStream(err error) chan schema.StreamPart {
+	data, _ := json.Marshal(map[string]string{"error": "ValidationError", "message": err.Error()})
+	streamCh := make(chan schema.StreamPart, 1)
+	streamCh <- schema.StreamPart{Event: "error", Data: string(data)}
+	close(streamCh)
+	return streamCh
+}
+
 func (c *RunsClient) Stream(ctx context.Context, threadID string, assistantID string, input *map[string]any, command *schema.Command, streamMode *[]schema.StreamMode, streamSubgraphs *bool, metadata *map[string]any, config *schema.Config, checkpoint *schema.Checkpoint, checkpointID *string, checkpointDuring *bool, interruptBefore *[]string, interruptAfter *[]string, feedbackKeys *[]string, webhook *string, multitaskStrategy *schema.MultitaskStrategy, ifNotExists *schema.IfNotExists, onDisconnect *schema.DisconnectMode, onCompletion *schema.OnCompletionBehavior, afterSeconds *int, headers *map[string]string) (chan schema.StreamPart, context.CancelFunc) {
+	if err := c.validateRun(ctx, threadID, assistantID, input, config, interruptBefore, interruptAfter, headers); err != nil {
+		return errorStream(err), func() {}
+	}
+
 	if streamMode != nil {
 		streamMode = new([]schema.StreamMode)
 		*streamMode = []schema.StreamMode{schema.StreamModeValues}
@@ -76,6 +128,10 @@ func (c *RunsClient) Stream(ctx context.Context, threadID string, assistantID st
 }
 
 func (c *RunsClient) Create(ctx context.Context, threadID string, assistantID string, input *map[string]any, command *schema.Command, streamMode *[]schema.StreamMode, streamSubgraphs *bool, metadata *map[string]any, config *schema.Config, checkpoint *schema.Checkpoint, checkpointID *string, checkpointDuring *bool, interruptBefore *[]string, interruptAfter *[]string, webhook *string, multitaskStrategy *schema.MultitaskStrategy, ifNotExists *schema.IfNotExists, onCompletion *schema.OnCompletionBehavior, afterSeconds *int, headers *map[string]string) (schema.Run, error) {
+	if err := c.validateRun(ctx, threadID, assistantID, input, config, interruptBefore, interruptAfter, headers); err != nil {
+		return schema.Run{}, err
+	}
+
 	payload := map[string]any{
 		"input":              input,
 		"command":            command,
@@ -154,6 +210,10 @@ func (c *RunsClient) CreateBatch(ctx context.Context, payloads []map[string]any)
 }
 
 func (c *RunsClient) Wait(ctx context.Context, threadID string, assistantID string, input *map[string]any, command *schema.Command, metadata *map[string]any, config *schema.Config, checkPoint schema.Checkpoint, checkPointID *string, checkpointDuring *bool, interruptBefore *[]string, interruptAfter *[]string, webhook *string, onDisconnect *schema.DisconnectMode, onCompletion *schema.OnCompletionBehavior, multitaskStrategy *schema.MultitaskStrategy, ifNotExists *schema.IfNotExists, afterSeconds *int, raiseError *bool, headers *map[string]string) (any, error) {
+	if err := c.validateRun(ctx, threadID, assistantID, input, config, interruptBefore, interruptAfter, headers); err != nil {
+		return nil, err
+	}
+
 	payload := map[string]any{
 		"input":              input,
 		"command":            command,
@@ -0,0 +1,572 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// MissedRunPolicy controls what a LocalScheduler does with fire times that
+// passed while it was not running
+type MissedRunPolicy string
+
+const (
+	MissedRunSkip MissedRunPolicy = "skip" // Drop missed fire times and wait for the next one
+	MissedRunOnce MissedRunPolicy = "once" // Fire once for any number of missed fire times
+	MissedRunAll  MissedRunPolicy = "all"  // Fire once for every missed fire time
+)
+
+// LocalSchedulerOptions configures a LocalScheduler
+type LocalSchedulerOptions struct {
+	StateFile  string             // File the schedules are persisted to; empty keeps them in memory only
+	MissedRuns MissedRunPolicy    // Catch-up policy applied on Start; defaults to MissedRunSkip
+	Location   *time.Location     // Time zone schedules are evaluated in; defaults to UTC
+	OnRun      func(LocalCronRun) // Called after every fire, including skipped ones
+	Headers    *map[string]string // Headers used for crons without their own, e.g. after loading the state file
+}
+
+// LocalCronRun reports a single fire of a locally scheduled cron
+type LocalCronRun struct {
+	CronID      string     // The ID of the cron that fired
+	ScheduledAt time.Time  // The fire time the run was created for
+	Run         schema.Run // The created run; empty if skipped or failed
+	Skipped     bool       // The previous run was still active and the cron's strategy is reject
+	Err         error      // The error creating the run, if any
+}
+
+// localCron is a cron as persisted in the state file. Headers are kept in
+// memory only so that credentials are not written to disk.
+type localCron struct {
+	Cron    schema.Cron      `json:"cron"`
+	Run     localCronPayload `json:"run"`
+	LastRun *time.Time       `json:"last_run,omitempty"`
+	LastRef *localRunRef     `json:"last_run_ref,omitempty"`
+
+	headers  *map[string]string
+	schedule *CronSchedule
+	// fired is closed when the job's latest fire has finished. Fires of a
+	// job run one after another so each sees the run its predecessor created.
+	fired chan struct{}
+}
+
+type localCronPayload struct {
+	Input             *map[string]any           `json:"input,omitempty"`
+	Metadata          *map[string]any           `json:"metadata,omitempty"`
+	Config            *schema.Config            `json:"config,omitempty"`
+	InterruptBefore   *[]string                 `json:"interrupt_before,omitempty"`
+	InterruptAfter    *[]string                 `json:"interrupt_after,omitempty"`
+	Webhook           *string                   `json:"webhook,omitempty"`
+	MultitaskStrategy *schema.MultitaskStrategy `json:"multitask_strategy,omitempty"`
+}
+
+type localRunRef struct {
+	ThreadID string `json:"thread_id"`
+	RunID    string `json:"run_id"`
+}
+
+// LocalScheduler runs cron jobs in-process for servers without the cron
+// subsystem. It creates runs with RunsClient.Create at each fire time.
+type LocalScheduler struct {
+	runs    *RunsClient
+	options LocalSchedulerOptions
+	now     func() time.Time
+
+	mu      sync.Mutex
+	crons   map[string]*localCron
+	wake    chan struct{}
+	cancel  context.CancelFunc
+	done    chan struct{}
+	stopRun context.CancelFunc
+	firing  sync.WaitGroup
+}
+
+// NewLocalScheduler creates a scheduler that creates runs with the given
+// client, loading previously persisted crons from options.StateFile if it
+// exists.
+func NewLocalScheduler(runs *RunsClient, options LocalSchedulerOptions) (*LocalScheduler, error) {
+	if options.MissedRuns == "" {
+		options.MissedRuns = MissedRunSkip
+	}
+	if options.Location == nil {
+		options.Location = time.UTC
+	}
+
+	s := &LocalScheduler{
+		runs:    runs,
+		options: options,
+		now:     time.Now,
+		crons:   map[string]*localCron{},
+		wake:    make(chan struct{}, 1),
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// CreateForThread schedules runs on a thread. It takes the same arguments as
+// CronsClient.CreatForThread, with interrupts given as node lists.
+func (s *LocalScheduler) CreateForThread(ctx context.Context, threadID string, assistantID string, schedule string, input *map[string]any, metadata *map[string]any, config *schema.Config, interruptBefore *[]string, interruptAfter *[]string, webhook *string, multitaskStrategy *schema.MultitaskStrategy, headers *map[string]string) (schema.Cron, error) {
+	return s.add(ctx, &threadID, assistantID, schedule, localCronPayload{
+		Input:             input,
+		Metadata:          metadata,
+		Config:            config,
+		InterruptBefore:   interruptBefore,
+		InterruptAfter:    interruptAfter,
+		Webhook:           webhook,
+		MultitaskStrategy: multitaskStrategy,
+	}, headers)
+}
+
+// Create schedules stateless runs. It takes the same arguments as
+// CronsClient.Creat, with interrupts given as node lists.
+func (s *LocalScheduler) Create(ctx context.Context, assistantID string, schedule string, input *map[string]any, metadata *map[string]any, config *schema.Config, interruptBefore *[]string, interruptAfter *[]string, webhook *string, multitaskStrategy *schema.MultitaskStrategy, headers *map[string]string) (schema.Cron, error) {
+	return s.add(ctx, nil, assistantID, schedule, localCronPayload{
+		Input:             input,
+		Metadata:          metadata,
+		Config:            config,
+		InterruptBefore:   interruptBefore,
+		InterruptAfter:    interruptAfter,
+		Webhook:           webhook,
+		MultitaskStrategy: multitaskStrategy,
+	}, headers)
+}
+
+func (s *LocalScheduler) add(ctx context.Context, threadID *string, assistantID string, schedule string, payload localCronPayload, headers *map[string]string) (schema.Cron, error) {
+	if err := ctx.Err(); err != nil {
+		return schema.Cron{}, err
+	}
+
+	parsed, err := ParseCronSchedule(schedule)
+	if err != nil {
+		return schema.Cron{}, err
+	}
+
+	id, err := newCronID()
+	if err != nil {
+		return schema.Cron{}, err
+	}
+
+	body, ok := normalizeJSON(payload).(map[string]any)
+	if !ok {
+		return schema.Cron{}, fmt.Errorf("invalid cron payload")
+	}
+	body["assistant_id"] = assistantID
+
+	now := s.now().UTC()
+	job := &localCron{
+		Cron: schema.Cron{
+			CronID:      id,
+			AssistantID: &assistantID,
+			ThreadID:    threadID,
+			Schedule:    schedule,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			Payload:     body,
+		},
+		Run:      payload,
+		headers:  headers,
+		schedule: parsed.In(s.options.Location),
+	}
+	if metadata := payload.Metadata; metadata != nil {
+		job.Cron.Metadata = *metadata
+	}
+
+	s.mu.Lock()
+	s.crons[id] = job
+	err = s.saveLocked()
+	cron := s.cronLocked(job)
+	s.mu.Unlock()
+	if err != nil {
+		return schema.Cron{}, err
+	}
+
+	s.notify()
+
+	return cron, nil
+}
+
+// Delete removes a cron. Runs already created are not cancelled.
+func (s *LocalScheduler) Delete(cronID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.crons[cronID]; !ok {
+		return fmt.Errorf("cron '%s' not found", cronID)
+	}
+	delete(s.crons, cronID)
+
+	return s.saveLocked()
+}
+
+// List returns the scheduled crons ordered by creation time, with
+// NextRunDate set to their next fire time.
+func (s *LocalScheduler) List() []schema.Cron {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	crons := make([]schema.Cron, 0, len(s.crons))
+	for _, job := range s.crons {
+		crons = append(crons, s.cronLocked(job))
+	}
+	sort.Slice(crons, func(i, j int) bool {
+		if crons[i].CreatedAt.Equal(crons[j].CreatedAt) {
+			return crons[i].CronID < crons[j].CronID
+		}
+		return crons[i].CreatedAt.Before(crons[j].CreatedAt)
+	})
+
+	return crons
+}
+
+// Start catches up on missed fire times according to the MissedRuns policy
+// and then fires crons in the background until Stop is called or ctx is
+// cancelled.
+func (s *LocalScheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.mu.Unlock()
+		return errors.New("scheduler already started")
+	}
+	loopCtx, cancel := context.WithCancel(ctx)
+	// Runs in flight are given until Stop's deadline to finish.
+	runCtx, stopRun := context.WithCancel(context.WithoutCancel(ctx))
+	s.cancel, s.stopRun = cancel, stopRun
+	s.done = make(chan struct{})
+	s.mu.Unlock()
+
+	s.catchUp(runCtx)
+
+	go s.loop(loopCtx, runCtx)
+
+	return nil
+}
+
+// Stop stops firing crons and waits for runs being created to finish. If ctx
+// is done first, the pending requests are cancelled and ctx's error returned.
+// The schedules are persisted before Stop returns.
+func (s *LocalScheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel, stopRun, done := s.cancel, s.stopRun, s.done
+	s.cancel, s.stopRun = nil, nil
+	s.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+	<-done
+
+	finished := make(chan struct{})
+	go func() {
+		s.firing.Wait()
+		close(finished)
+	}()
+
+	var err error
+	select {
+	case <-finished:
+	case <-ctx.Done():
+		err = ctx.Err()
+		stopRun()
+		<-finished
+	}
+	stopRun()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if saveErr := s.saveLocked(); saveErr != nil && err == nil {
+		err = saveErr
+	}
+
+	return err
+}
+
+func (s *LocalScheduler) loop(ctx context.Context, runCtx context.Context) {
+	defer close(s.done)
+
+	for {
+		now := s.now()
+
+		s.mu.Lock()
+		var next time.Time
+		fired := false
+		for _, job := range s.crons {
+			// Fire times passed while the process was suspended collapse
+			// into a single run.
+			if fireAt := job.schedule.lastBefore(job.since(), now); !fireAt.IsZero() {
+				job.LastRun = &fireAt
+				s.fire(runCtx, job, fireAt)
+				fired = true
+			}
+			fireAt := job.schedule.Next(job.since())
+			if !fireAt.IsZero() && (next.IsZero() || fireAt.Before(next)) {
+				next = fireAt
+			}
+		}
+		if fired {
+			_ = s.saveLocked()
+		}
+		s.mu.Unlock()
+
+		var timer *time.Timer
+		var fire <-chan time.Time
+		if !next.IsZero() {
+			timer = time.NewTimer(next.Sub(now))
+			fire = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-s.wake:
+		case <-fire:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (s *LocalScheduler) catchUp(ctx context.Context) {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, job := range s.crons {
+		since := job.since()
+		missed := missedRuns(job.schedule, since, now, s.options.MissedRuns)
+		for _, fireAt := range missed {
+			s.fire(ctx, job, fireAt)
+		}
+		// Skipped fire times count as handled so they are not caught up again.
+		if last := job.schedule.lastBefore(since, now); !last.IsZero() {
+			job.LastRun = &last
+		}
+	}
+
+	_ = s.saveLocked()
+}
+
+// fire creates a run for job in the background, after the job's earlier
+// fires have finished. Callers hold s.mu.
+func (s *LocalScheduler) fire(ctx context.Context, job *localCron, scheduledAt time.Time) {
+	headers := job.headers
+	if headers == nil {
+		headers = s.options.Headers
+	}
+	threadID := ""
+	if job.Cron.ThreadID != nil {
+		threadID = *job.Cron.ThreadID
+	}
+	payload := job.Run
+	wait := job.fired
+	fired := make(chan struct{})
+	job.fired = fired
+
+	s.firing.Add(1)
+	go func() {
+		defer s.firing.Done()
+		defer close(fired)
+
+		if wait != nil {
+			<-wait
+		}
+		s.mu.Lock()
+		previous := job.LastRef
+		s.mu.Unlock()
+
+		result := LocalCronRun{CronID: job.Cron.CronID, ScheduledAt: scheduledAt}
+		skip, err := s.resolveOverlap(ctx, previous, payload.MultitaskStrategy, headers)
+		switch {
+		case err != nil:
+			result.Err = err
+		case skip:
+			result.Skipped = true
+		default:
+			result.Run, result.Err = s.runs.Create(ctx, threadID, *job.Cron.AssistantID, payload.Input, nil, nil, nil, payload.Metadata, payload.Config, nil, nil, nil, payload.InterruptBefore, payload.InterruptAfter, payload.Webhook, payload.MultitaskStrategy, nil, nil, nil, headers)
+			if result.Err == nil {
+				s.mu.Lock()
+				job.LastRef = &localRunRef{ThreadID: result.Run.ThreadID, RunID: result.Run.RunID}
+				s.mu.Unlock()
+			}
+		}
+
+		if s.options.OnRun != nil {
+			s.options.OnRun(result)
+		}
+	}()
+}
+
+// resolveOverlap applies the multitask strategy when the previous run of a
+// cron is still active: reject (the default) skips the fire, interrupt and
+// rollback cancel the previous run, and enqueue lets both run.
+func (s *LocalScheduler) resolveOverlap(ctx context.Context, previous *localRunRef, strategy *schema.MultitaskStrategy, headers *map[string]string) (bool, error) {
+	if previous == nil {
+		return false, nil
+	}
+	if strategy != nil && *strategy == schema.MultitaskStrategyEnqueue {
+		return false, nil
+	}
+
+	run, err := s.runs.Get(ctx, previous.ThreadID, previous.RunID, headers)
+	if err != nil || run.Status != schema.RunStatusPending && run.Status != schema.RunStatusRunning {
+		// A run that can no longer be fetched is not blocking anything.
+		return false, nil
+	}
+
+	if strategy == nil || *strategy == schema.MultitaskStrategyReject {
+		return true, nil
+	}
+
+	action := schema.CancelActionInterrupt
+	if *strategy == schema.MultitaskStrategyRollback {
+		action = schema.CancelActionRollback
+	}
+	wait := true
+	return false, s.runs.Cancel(ctx, previous.ThreadID, previous.RunID, &wait, &action, headers)
+}
+
+// missedRuns returns the fire times in (since, now] to create runs for under
+// the given policy.
+func missedRuns(schedule *CronSchedule, since time.Time, now time.Time, policy MissedRunPolicy) []time.Time {
+	switch policy {
+	case MissedRunOnce:
+		if last := schedule.lastBefore(since, now); !last.IsZero() {
+			return []time.Time{last}
+		}
+	case MissedRunAll:
+		var missed []time.Time
+		for t := schedule.Next(since); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+			missed = append(missed, t)
+		}
+		return missed
+	}
+	return nil
+}
+
+// lastBefore returns the last fire time in (since, now], or the zero time.
+func (s *CronSchedule) lastBefore(since time.Time, now time.Time) time.Time {
+	var last time.Time
+	for t := s.Next(since); !t.IsZero() && !t.After(now); t = s.Next(t) {
+		last = t
+	}
+	return last
+}
+
+// since returns the time after which the cron's next fire time falls: its
+// last fire time, or its creation time if it has not fired yet.
+func (j *localCron) since() time.Time {
+	if j.LastRun != nil {
+		return *j.LastRun
+	}
+	return j.Cron.CreatedAt
+}
+
+func (s *LocalScheduler) cronLocked(job *localCron) schema.Cron {
+	cron := job.Cron
+	since := job.since()
+	if now := s.now(); now.After(since) {
+		since = now
+	}
+	if next := job.schedule.Next(since); !next.IsZero() {
+		cron.NextRunDate = &next
+	}
+	return cron
+}
+
+func (s *LocalScheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *LocalScheduler) load() error {
+	if s.options.StateFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.options.StateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var jobs []*localCron
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return fmt.Errorf("%s: %w", s.options.StateFile, err)
+	}
+
+	for _, job := range jobs {
+		schedule, err := ParseCronSchedule(job.Cron.Schedule)
+		if err != nil {
+			return fmt.Errorf("%s: cron '%s': %w", s.options.StateFile, job.Cron.CronID, err)
+		}
+		job.schedule = schedule.In(s.options.Location)
+		s.crons[job.Cron.CronID] = job
+	}
+
+	return nil
+}
+
+// saveLocked writes the crons to the state file through a temporary file so
+// that a crash never leaves a truncated file behind. Callers hold s.mu.
+func (s *LocalScheduler) saveLocked() error {
+	if s.options.StateFile == "" {
+		return nil
+	}
+
+	jobs := make([]*localCron, 0, len(s.crons))
+	for _, job := range s.crons {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Cron.CronID < jobs[j].Cron.CronID })
+
+	data, err := json.MarshalIndent(jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.options.StateFile), filepath.Base(s.options.StateFile)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.options.StateFile)
+}
+
+func newCronID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
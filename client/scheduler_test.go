@@ -0,0 +1,235 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/http"
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissedRuns(t *testing.T) {
+	schedule, err := ParseCronSchedule("0 * * * *")
+	assert.NoError(t, err)
+
+	since := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 1, 1, 12, 30, 0, 0, time.UTC)
+
+	assert.Empty(t, missedRuns(schedule, since, now, MissedRunSkip))
+	assert.Equal(t, []time.Time{time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}, missedRuns(schedule, since, now, MissedRunOnce))
+	assert.Equal(t, []time.Time{
+		time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}, missedRuns(schedule, since, now, MissedRunAll))
+	assert.Empty(t, missedRuns(schedule, now, now, MissedRunAll))
+}
+
+func TestLocalSchedulerPersistence(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "crons.json")
+	now := time.Date(2025, 1, 1, 9, 10, 0, 0, time.UTC)
+
+	scheduler, err := NewLocalScheduler(nil, LocalSchedulerOptions{StateFile: stateFile})
+	assert.NoError(t, err)
+	scheduler.now = func() time.Time { return now }
+
+	threadID := "thread-1"
+	input := map[string]any{"query": "report"}
+	strategy := schema.MultitaskStrategyEnqueue
+	created, err := scheduler.CreateForThread(context.Background(), threadID, "agent", "*/30 * * * *", &input, nil, nil, nil, nil, nil, &strategy, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 1, 9, 30, 0, 0, time.UTC), *created.NextRunDate)
+
+	_, err = scheduler.Create(context.Background(), "agent", "every minute", nil, nil, nil, nil, nil, nil, nil, nil)
+	assert.Error(t, err)
+
+	reloaded, err := NewLocalScheduler(nil, LocalSchedulerOptions{StateFile: stateFile})
+	assert.NoError(t, err)
+	reloaded.now = func() time.Time { return now }
+
+	crons := reloaded.List()
+	assert.Len(t, crons, 1)
+	assert.Equal(t, created.CronID, crons[0].CronID)
+	assert.Equal(t, &threadID, crons[0].ThreadID)
+	assert.Equal(t, "report", crons[0].Payload["input"].(map[string]any)["query"])
+	assert.Equal(t, "enqueue", crons[0].Payload["multitask_strategy"])
+
+	assert.NoError(t, reloaded.Delete(created.CronID))
+	assert.Error(t, reloaded.Delete(created.CronID))
+
+	reloaded, err = NewLocalScheduler(nil, LocalSchedulerOptions{StateFile: stateFile})
+	assert.NoError(t, err)
+	assert.Empty(t, reloaded.List())
+
+	assert.NoError(t, reloaded.Start(context.Background()))
+	assert.Error(t, reloaded.Start(context.Background()))
+	assert.NoError(t, reloaded.Stop(context.Background()))
+}
+
+// fakeRunsServer creates runs on t1 that stay in status until changed, and
+// records the requests made against them.
+type fakeRunsServer struct {
+	mu       sync.Mutex
+	status   schema.RunStatus
+	created  int
+	requests []string
+	release  chan struct{} // If set, creates block until it is closed or the request is cancelled
+}
+
+func (f *fakeRunsServer) serve(req *nethttp.Request) (int, string) {
+	f.mu.Lock()
+	route := req.Method + " " + req.URL.Path
+	if strings.HasSuffix(route, "/cancel") {
+		data, _ := io.ReadAll(req.Body)
+		var body map[string]any
+		json.Unmarshal(data, &body)
+		route += fmt.Sprintf(" %v", body["action"])
+	}
+	f.requests = append(f.requests, route)
+	release := f.release
+	f.mu.Unlock()
+
+	switch {
+	case req.Method == nethttp.MethodGet:
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		return 200, fmt.Sprintf(`{"run_id":"%s","thread_id":"t1","status":"%s"}`, req.URL.Path[len("/threads/t1/runs/"):], f.status)
+	case strings.HasSuffix(req.URL.Path, "/cancel"):
+		return 200, `{}`
+	}
+
+	if release != nil {
+		select {
+		case <-release:
+		case <-req.Context().Done():
+			return 499, `{"detail":"cancelled"}`
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.created++
+	return 200, fmt.Sprintf(`{"run_id":"r%d","thread_id":"t1","status":"%s"}`, f.created, f.status)
+}
+
+func newTestLocalScheduler(t *testing.T, server *fakeRunsServer, now time.Time, options LocalSchedulerOptions) (*LocalScheduler, *[]LocalCronRun) {
+	var mu sync.Mutex
+	results := []LocalCronRun{}
+	options.OnRun = func(run LocalCronRun) {
+		mu.Lock()
+		defer mu.Unlock()
+		results = append(results, run)
+	}
+	runs := NewRunsClient(http.NewHttpClient("http://runs.test", nil, 5*time.Second, roundTripFunc(server.serve)))
+	scheduler, err := NewLocalScheduler(runs, options)
+	assert.NoError(t, err)
+	scheduler.now = func() time.Time { return now }
+	return scheduler, &results
+}
+
+func TestLocalSchedulerFireStrategies(t *testing.T) {
+	now := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	reject := schema.MultitaskStrategyReject
+	interrupt := schema.MultitaskStrategyInterrupt
+	rollback := schema.MultitaskStrategyRollback
+	enqueue := schema.MultitaskStrategyEnqueue
+	for _, tc := range []struct {
+		strategy *schema.MultitaskStrategy
+		status   schema.RunStatus
+		skipped  bool
+		requests []string
+	}{
+		{nil, schema.RunStatusRunning, true, []string{"GET /threads/t1/runs/r0"}},
+		{&reject, schema.RunStatusPending, true, []string{"GET /threads/t1/runs/r0"}},
+		{&reject, schema.RunStatusSuccess, false, []string{"GET /threads/t1/runs/r0", "POST /threads/t1/runs"}},
+		{&interrupt, schema.RunStatusRunning, false, []string{"GET /threads/t1/runs/r0", "POST /threads/t1/runs/r0/cancel interrupt", "POST /threads/t1/runs"}},
+		{&rollback, schema.RunStatusRunning, false, []string{"GET /threads/t1/runs/r0", "POST /threads/t1/runs/r0/cancel rollback", "POST /threads/t1/runs"}},
+		{&enqueue, schema.RunStatusRunning, false, []string{"POST /threads/t1/runs"}},
+	} {
+		server := &fakeRunsServer{status: tc.status}
+		scheduler, results := newTestLocalScheduler(t, server, now, LocalSchedulerOptions{})
+		cron, err := scheduler.CreateForThread(context.Background(), "t1", "agent", "0 * * * *", nil, nil, nil, nil, nil, nil, tc.strategy, nil)
+		assert.NoError(t, err)
+
+		scheduler.mu.Lock()
+		job := scheduler.crons[cron.CronID]
+		job.LastRef = &localRunRef{ThreadID: "t1", RunID: "r0"}
+		scheduler.fire(context.Background(), job, now)
+		scheduler.mu.Unlock()
+		scheduler.firing.Wait()
+
+		assert.Equal(t, tc.requests, server.requests, "strategy %v, status %s", tc.strategy, tc.status)
+		assert.Len(t, *results, 1)
+		assert.NoError(t, (*results)[0].Err)
+		assert.Equal(t, tc.skipped, (*results)[0].Skipped)
+		if !tc.skipped {
+			assert.Equal(t, "r1", (*results)[0].Run.RunID)
+			assert.Equal(t, &localRunRef{ThreadID: "t1", RunID: "r1"}, job.LastRef)
+		}
+	}
+}
+
+func TestLocalSchedulerCatchUpRunsInOrder(t *testing.T) {
+	created := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	server := &fakeRunsServer{status: schema.RunStatusRunning}
+	scheduler, results := newTestLocalScheduler(t, server, created, LocalSchedulerOptions{MissedRuns: MissedRunAll})
+	_, err := scheduler.CreateForThread(context.Background(), "t1", "agent", "0 * * * *", nil, nil, nil, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+
+	// Three fire times were missed. The first creates a run that is still
+	// running when the others fire, so they are rejected.
+	scheduler.now = func() time.Time { return created.Add(3*time.Hour + 30*time.Minute) }
+	assert.NoError(t, scheduler.Start(context.Background()))
+	assert.NoError(t, scheduler.Stop(context.Background()))
+
+	assert.Equal(t, []string{"POST /threads/t1/runs", "GET /threads/t1/runs/r1", "GET /threads/t1/runs/r1"}, server.requests)
+	assert.Len(t, *results, 3)
+	for i, result := range *results {
+		assert.Equal(t, created.Add(time.Duration(i+1)*time.Hour), result.ScheduledAt)
+		assert.Equal(t, i > 0, result.Skipped)
+	}
+}
+
+func TestLocalSchedulerStopWaitsForRuns(t *testing.T) {
+	created := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	server := &fakeRunsServer{status: schema.RunStatusPending, release: make(chan struct{})}
+	scheduler, results := newTestLocalScheduler(t, server, created, LocalSchedulerOptions{MissedRuns: MissedRunOnce})
+	_, err := scheduler.CreateForThread(context.Background(), "t1", "agent", "0 * * * *", nil, nil, nil, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+
+	scheduler.now = func() time.Time { return created.Add(90 * time.Minute) }
+	assert.NoError(t, scheduler.Start(context.Background()))
+
+	stopped := make(chan error)
+	go func() { stopped <- scheduler.Stop(context.Background()) }()
+	select {
+	case <-stopped:
+		t.Fatal("Stop returned while a run was being created")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(server.release)
+	assert.NoError(t, <-stopped)
+	assert.Len(t, *results, 1)
+	assert.Equal(t, "r1", (*results)[0].Run.RunID)
+
+	// A run still being created at Stop's deadline is cancelled.
+	server.mu.Lock()
+	server.status = schema.RunStatusSuccess
+	server.release = make(chan struct{})
+	server.mu.Unlock()
+	scheduler.now = func() time.Time { return created.Add(150 * time.Minute) }
+	assert.NoError(t, scheduler.Start(context.Background()))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, scheduler.Stop(ctx), context.DeadlineExceeded)
+	assert.Len(t, *results, 2)
+	assert.Error(t, (*results)[1].Err)
+}
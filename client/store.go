@@ -3,28 +3,57 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-
+	nethttp "net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/KhanhD1nh/langgraph-sdk-go/http"
 	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
 )
 
+// ErrItemNotFound is returned by Store.GetItem when the item does not exist
+var ErrItemNotFound = errors.New("store item not found")
+
+// Store is the item API of the LangGraph Store, implemented by StoreClient
+// against a server and by MemoryStore in-process
+type Store interface {
+	PutItem(ctx context.Context, namespace []string, key string, value map[string]any, index *any, ttl *time.Duration, headers *map[string]string) error
+	GetItem(ctx context.Context, namespace []string, key string, refreshTtl *bool, headers *map[string]string) (*schema.Item, error)
+	DeleteItem(ctx context.Context, namespace []string, key string, headers *map[string]string) error
+	SearchItems(ctx context.Context, namespace []string, filter *map[string]any, limit *int, offset *int, query *string, refreshTtl *bool, headers *map[string]string) (schema.SearchItemsResponse, error)
+	ListNamespaces(ctx context.Context, prefix *[]string, suffix *[]string, maxDepth *int, limit *int, offset *int, headers *map[string]string) ([]schema.ListNamespaceResponse, error)
+}
+
+var _ Store = (*StoreClient)(nil)
+
 type StoreClient struct {
-	http *http.HttpClient
+	http    *http.HttpClient
+	noBatch atomic.Bool // Set once the server is found not to support batch requests
+
+	ttlMu       sync.RWMutex
+	defaultTTLs map[string]time.Duration // Default TTL per "." joined namespace prefix
 }
 
 func NewStoreClient(httpClient *http.HttpClient) *StoreClient {
 	return &StoreClient{http: httpClient}
 }
 
-func (c *StoreClient) PutItem(ctx context.Context, namespace []string, key string, value map[string]any, index *any, ttl *int, headers *map[string]string) error {
-	for _, label := range namespace {
-		if containsDot(label) {
-			return fmt.Errorf("invalid namespace label '%s'. Namespace labels cannot contain periods ('.')", label)
-		}
+// PutItem stores an item. The TTL must be a positive whole number of
+// minutes; if it is nil, the default TTL set for the namespace with
+// SetDefaultTTL applies, if any.
+func (c *StoreClient) PutItem(ctx context.Context, namespace []string, key string, value map[string]any, index *any, ttl *time.Duration, headers *map[string]string) error {
+	if err := validateNamespace(namespace); err != nil {
+		return err
+	}
+
+	ttl, err := c.resolveTTL(namespace, ttl)
+	if err != nil {
+		return err
 	}
 
 	payload := map[string]any{
@@ -32,7 +61,9 @@ func (c *StoreClient) PutItem(ctx context.Context, namespace []string, key strin
 		"key":       key,
 		"value":     value,
 		"index":     index,
-		"ttl":       ttl,
+	}
+	if ttl != nil {
+		payload["ttl"] = ttlMinutes(*ttl)
 	}
 
 	payload, ok := removeEmptyFields(payload).(map[string]any)
@@ -40,17 +71,94 @@ func (c *StoreClient) PutItem(ctx context.Context, namespace []string, key strin
 		fmt.Println("Error: cleanedPayload is not a map[string]any")
 	}
 
-	_, err := c.http.Put(ctx, "/store/items", payload, headers)
+	_, err = c.http.Put(ctx, "/store/items", payload, headers)
 	return err
 }
 
-func (c *StoreClient) GetItem(ctx context.Context, namespace []string, key string, refreshTtl *bool, headers *map[string]string) (map[string]any, error) {
-	for _, label := range namespace {
-		if containsDot(label) {
-			return nil, fmt.Errorf("invalid namespace label '%s'. Namespace labels cannot contain periods ('.')", label)
+// SetDefaultTTL sets the TTL applied by PutItem and PutMany to items put
+// beneath a namespace prefix without an explicit TTL. The longest matching
+// prefix wins; an empty prefix sets the default for every namespace. A TTL
+// of zero removes the default for the prefix.
+func (c *StoreClient) SetDefaultTTL(prefix []string, ttl time.Duration) error {
+	if ttl != 0 {
+		if err := ValidateTTL(ttl); err != nil {
+			return err
 		}
 	}
 
+	c.ttlMu.Lock()
+	defer c.ttlMu.Unlock()
+
+	if c.defaultTTLs == nil {
+		c.defaultTTLs = map[string]time.Duration{}
+	}
+	if ttl == 0 {
+		delete(c.defaultTTLs, strings.Join(prefix, "."))
+	} else {
+		c.defaultTTLs[strings.Join(prefix, ".")] = ttl
+	}
+
+	return nil
+}
+
+// DefaultTTL returns the default TTL for items in namespace, or nil if none
+// applies.
+func (c *StoreClient) DefaultTTL(namespace []string) *time.Duration {
+	c.ttlMu.RLock()
+	defer c.ttlMu.RUnlock()
+
+	for n := len(namespace); n >= 0; n-- {
+		if ttl, ok := c.defaultTTLs[strings.Join(namespace[:n], ".")]; ok {
+			return &ttl
+		}
+	}
+	return nil
+}
+
+// Touch refreshes the TTL of an item. The server has no dedicated endpoint,
+// so the item is fetched with its TTL refreshed and the value discarded. It
+// returns ErrItemNotFound if the item does not exist.
+func (c *StoreClient) Touch(ctx context.Context, namespace []string, key string, headers *map[string]string) error {
+	refresh := true
+	_, err := c.GetItem(ctx, namespace, key, &refresh, headers)
+	return err
+}
+
+// ValidateTTL checks that ttl can be stored: the store keeps TTLs in whole
+// minutes.
+func ValidateTTL(ttl time.Duration) error {
+	if ttl <= 0 {
+		return fmt.Errorf("invalid ttl %s: must be positive", ttl)
+	}
+	if ttl%time.Minute != 0 {
+		return fmt.Errorf("invalid ttl %s: must be a whole number of minutes", ttl)
+	}
+	return nil
+}
+
+// resolveTTL validates an explicit TTL or falls back to the namespace default.
+func (c *StoreClient) resolveTTL(namespace []string, ttl *time.Duration) (*time.Duration, error) {
+	if ttl == nil {
+		return c.DefaultTTL(namespace), nil
+	}
+	if err := ValidateTTL(*ttl); err != nil {
+		return nil, err
+	}
+	return ttl, nil
+}
+
+// ttlMinutes converts a validated TTL to the minutes sent to the server.
+func ttlMinutes(ttl time.Duration) int {
+	return int(ttl / time.Minute)
+}
+
+// GetItem fetches a single item. It returns ErrItemNotFound if the namespace
+// has no item with the given key.
+func (c *StoreClient) GetItem(ctx context.Context, namespace []string, key string, refreshTtl *bool, headers *map[string]string) (*schema.Item, error) {
+	if err := validateNamespace(namespace); err != nil {
+		return nil, err
+	}
+
 	params := url.Values{}
 	params.Add("namespace", strings.Join(namespace, "."))
 	params.Add("key", key)
@@ -60,24 +168,31 @@ func (c *StoreClient) GetItem(ctx context.Context, namespace []string, key strin
 	}
 
 	resp, err := c.http.Get(ctx, "/store/items", params, headers)
+	var httpErr *http.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == nethttp.StatusNotFound {
+		return nil, fmt.Errorf("%w: '%s' in namespace '%s'", ErrItemNotFound, key, strings.Join(namespace, "."))
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	var item map[string]any
+	var item *schema.Item
 	err = json.Unmarshal(resp.Body(), &item)
 	if err != nil {
 		return nil, err
 	}
 
+	// Some server versions answer a miss with a null body.
+	if item == nil {
+		return nil, fmt.Errorf("%w: '%s' in namespace '%s'", ErrItemNotFound, key, strings.Join(namespace, "."))
+	}
+
 	return item, nil
 }
 
 func (c *StoreClient) DeleteItem(ctx context.Context, namespace []string, key string, headers *map[string]string) error {
-	for _, label := range namespace {
-		if containsDot(label) {
-			return fmt.Errorf("invalid namespace label '%s'. Namespace labels cannot contain periods ('.')", label)
-		}
+	if err := validateNamespace(namespace); err != nil {
+		return err
 	}
 
 	jsonData := map[string]any{
@@ -93,7 +208,7 @@ func (c *StoreClient) DeleteItem(ctx context.Context, namespace []string, key st
 	return nil
 }
 
-func (c *StoreClient) SearchItems(namespace []string, filter *map[string]any, limit *int, offset *int, query *string, refreshTtl *bool, headers *map[string]string) (schema.SearchItemsResponse, error) {
+func (c *StoreClient) SearchItems(ctx context.Context, namespace []string, filter *map[string]any, limit *int, offset *int, query *string, refreshTtl *bool, headers *map[string]string) (schema.SearchItemsResponse, error) {
 	if limit != nil && *limit <= 0 {
 		*limit = 10
 	}
@@ -116,7 +231,6 @@ func (c *StoreClient) SearchItems(namespace []string, filter *map[string]any, li
 		fmt.Println("Error: cleanedPayload is not a map[string]any")
 	}
 
-	ctx := context.Background()
 	resp, err := c.http.Post(ctx, "/store/items/search", payload, headers)
 	if err != nil {
 		return schema.SearchItemsResponse{}, err
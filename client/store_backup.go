@@ -0,0 +1,240 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// ImportPolicy decides what StoreClient.Import does with items that already exist
+type ImportPolicy string
+
+const (
+	ImportOverwrite    ImportPolicy = "overwrite"     // Replace existing items
+	ImportSkipExisting ImportPolicy = "skip_existing" // Keep existing items and skip the exported ones
+)
+
+// StoreRecord is one line of a store export
+type StoreRecord struct {
+	Namespace []string       `json:"namespace"`       // The namespace of the item
+	Key       string         `json:"key"`             // The key of the item
+	Value     map[string]any `json:"value"`           // The value of the item
+	CreatedAt time.Time      `json:"created_at"`      // The timestamp when the item was created
+	UpdatedAt time.Time      `json:"updated_at"`      // The timestamp when the item was last updated
+	Index     *any           `json:"index,omitempty"` // The index configuration to restore the item with
+}
+
+// StoreExportOptions holds the optional arguments of StoreClient.Export
+type StoreExportOptions struct {
+	Index   map[string]any     // Index configuration recorded per namespace, keyed by the "." joined namespace; the server does not return it
+	Headers *map[string]string // Headers sent with every request
+}
+
+// StoreImportOptions holds the optional arguments of StoreClient.Import
+type StoreImportOptions struct {
+	Policy     ImportPolicy       // Defaults to ImportOverwrite
+	FromPrefix []string           // Namespace prefix of the exported items to replace
+	ToPrefix   []string           // Namespace prefix to restore them under
+	TTL        *time.Duration     // Time to live of the restored items; nil applies the client's default TTL policy
+	Batch      StoreBatchOptions  // Options of the PutMany and GetMany calls used to restore items
+	Headers    *map[string]string // Headers sent with every request; overrides Batch.Headers
+}
+
+// StoreImportResult summarises a StoreClient.Import call
+type StoreImportResult struct {
+	Written int                // Number of items written
+	Skipped int                // Number of existing items skipped
+	Failed  []StoreBatchResult // Items that could not be written
+}
+
+// storePageSize is the page size used to walk namespaces and items.
+const storePageSize = 100
+
+// Export writes every item in the namespaces beneath prefix to w as JSON
+// lines, one StoreRecord per item. Items are listed page by page and each
+// page is written as it arrives, so the order follows the search results. It
+// returns the number of items written.
+func (c *StoreClient) Export(ctx context.Context, prefix []string, w io.Writer, opts *StoreExportOptions) (int, error) {
+	if opts == nil {
+		opts = &StoreExportOptions{}
+	}
+
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+	written := 0
+	for offset := 0; ; offset += storePageSize {
+		limit, pageOffset := storePageSize, offset
+		page, err := c.SearchItems(ctx, prefix, nil, &limit, &pageOffset, nil, nil, opts.Headers)
+		if err != nil {
+			return written, err
+		}
+
+		for _, item := range page.Items {
+			var index *any
+			if config, ok := opts.Index[strings.Join(item.Namespace, ".")]; ok {
+				index = &config
+			}
+
+			record := StoreRecord{
+				Namespace: item.Namespace,
+				Key:       item.Key,
+				Value:     item.Value,
+				CreatedAt: item.CreatedAt,
+				UpdatedAt: item.UpdatedAt,
+				Index:     index,
+			}
+			if err := encoder.Encode(record); err != nil {
+				return written, err
+			}
+			written++
+		}
+		if err := buffered.Flush(); err != nil {
+			return written, err
+		}
+
+		if len(page.Items) < storePageSize {
+			return written, nil
+		}
+	}
+}
+
+// Import restores items exported with Export. Items are written in batches
+// with PutMany; the creation and update timestamps are set by the server.
+func (c *StoreClient) Import(ctx context.Context, r io.Reader, opts *StoreImportOptions) (StoreImportResult, error) {
+	if opts == nil {
+		opts = &StoreImportOptions{}
+	}
+	batch := opts.Batch
+	if opts.Headers != nil {
+		batch.Headers = opts.Headers
+	}
+
+	result := StoreImportResult{}
+	pending := []StoreItemPut{}
+
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+		puts := pending
+		pending = []StoreItemPut{}
+
+		if opts.Policy == ImportSkipExisting {
+			keys := make([]StoreItemKey, len(puts))
+			for i, put := range puts {
+				keys[i] = StoreItemKey{Namespace: put.Namespace, Key: put.Key}
+			}
+			existing, err := c.GetMany(ctx, keys, &batch)
+			if err != nil {
+				return err
+			}
+			missing := puts[:0]
+			for i, res := range existing {
+				switch {
+				case res.Err == nil:
+					result.Skipped++
+				case errors.Is(res.Err, ErrItemNotFound):
+					missing = append(missing, puts[i])
+				default:
+					result.Failed = append(result.Failed, res)
+				}
+			}
+			puts = missing
+		}
+
+		results, err := c.PutMany(ctx, puts, &batch)
+		for _, res := range results {
+			if res.Err != nil {
+				result.Failed = append(result.Failed, res)
+			} else {
+				result.Written++
+			}
+		}
+		return err
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
+		var record StoreRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return result, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		namespace, err := remapNamespace(record.Namespace, opts.FromPrefix, opts.ToPrefix)
+		if err != nil {
+			return result, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		pending = append(pending, StoreItemPut{
+			Namespace: namespace,
+			Key:       record.Key,
+			Value:     record.Value,
+			Index:     record.Index,
+			TTL:       opts.TTL,
+		})
+		if len(pending) >= storeBatchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return result, err
+	}
+
+	return result, flush()
+}
+
+// remapNamespace replaces the from prefix of namespace with to. Namespaces
+// outside from are an error, since they would be restored to the wrong place.
+func remapNamespace(namespace []string, from []string, to []string) ([]string, error) {
+	if len(from) == 0 && len(to) == 0 {
+		return namespace, nil
+	}
+	if len(namespace) < len(from) || !slices.Equal(namespace[:len(from)], from) {
+		return nil, fmt.Errorf("namespace '%s' is not under '%s'", strings.Join(namespace, "."), strings.Join(from, "."))
+	}
+	return append(append([]string{}, to...), namespace[len(from):]...), nil
+}
+
+// itemsUnder returns every item beneath prefix, grouped by "." joined
+// namespace, and the namespaces holding them in sorted order. The items are
+// listed with a single walk over the prefix.
+func (c *StoreClient) itemsUnder(ctx context.Context, prefix []string, headers *map[string]string) ([][]string, map[string][]schema.SearchItem, error) {
+	namespaces := [][]string{}
+	items := map[string][]schema.SearchItem{}
+	for offset := 0; ; offset += storePageSize {
+		limit, pageOffset := storePageSize, offset
+		page, err := c.SearchItems(ctx, prefix, nil, &limit, &pageOffset, nil, nil, headers)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, item := range page.Items {
+			name := strings.Join(item.Namespace, ".")
+			if _, ok := items[name]; !ok {
+				namespaces = append(namespaces, item.Namespace)
+			}
+			items[name] = append(items[name], item)
+		}
+		if len(page.Items) < storePageSize {
+			slices.SortFunc(namespaces, slices.Compare)
+			return namespaces, items, nil
+		}
+	}
+}
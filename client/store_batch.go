@@ -0,0 +1,322 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/http"
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// ErrBatchStopped is reported for the operations of a batch that were not
+// attempted because an earlier one failed and StopOnError was set
+var ErrBatchStopped = errors.New("batch stopped after an earlier failure")
+
+// storeBatchSize is the maximum number of operations sent per batch request.
+const storeBatchSize = 100
+
+// StoreItemPut is a single write of StoreClient.PutMany
+type StoreItemPut struct {
+	Namespace []string       // The namespace of the item
+	Key       string         // The key of the item
+	Value     map[string]any // The value to store
+	Index     *any           // Fields to index for search; false disables indexing
+	TTL       *time.Duration // Time to live; nil applies the client's default TTL policy
+}
+
+// StoreItemKey identifies an item for StoreClient.GetMany and DeleteMany
+type StoreItemKey struct {
+	Namespace []string // The namespace of the item
+	Key       string   // The key of the item
+}
+
+// StoreBatchOptions configures the batched store operations
+type StoreBatchOptions struct {
+	Concurrency int                // Maximum concurrent requests when the batch endpoint is unavailable; defaults to 8
+	StopOnError bool               // Skip the remaining operations after the first failure; an invalid operation fails the batch before anything is sent
+	RefreshTTL  *bool              // Refresh the TTL of items read by GetMany
+	Headers     *map[string]string // Headers sent with every request
+}
+
+// StoreBatchResult is the outcome of one operation of a batch. Results are
+// returned in the order of the operations.
+type StoreBatchResult struct {
+	Namespace []string     // The namespace of the item
+	Key       string       // The key of the item
+	Item      *schema.Item // The item read by GetMany; nil otherwise
+	Err       error        // The error of the operation; ErrItemNotFound for a GetMany miss
+}
+
+type storeOp struct {
+	kind string
+	StoreItemPut
+}
+
+// PutMany writes items, using the server's batch endpoint when available and
+// otherwise concurrent PutItem calls. Writes to the same item are applied in
+// the order given. The returned error is the first failure when StopOnError
+// is set, or the context's error.
+func (c *StoreClient) PutMany(ctx context.Context, items []StoreItemPut, opts *StoreBatchOptions) ([]StoreBatchResult, error) {
+	ops := make([]storeOp, len(items))
+	for i, item := range items {
+		ops[i] = storeOp{kind: "put", StoreItemPut: item}
+	}
+	return c.batch(ctx, ops, opts)
+}
+
+// GetMany reads items, using the server's batch endpoint when available and
+// otherwise concurrent GetItem calls. Missing items are reported with
+// ErrItemNotFound and do not count as failures for StopOnError.
+func (c *StoreClient) GetMany(ctx context.Context, keys []StoreItemKey, opts *StoreBatchOptions) ([]StoreBatchResult, error) {
+	return c.batch(ctx, keyOps("get", keys), opts)
+}
+
+// DeleteMany deletes items, using the server's batch endpoint when available
+// and otherwise concurrent DeleteItem calls.
+func (c *StoreClient) DeleteMany(ctx context.Context, keys []StoreItemKey, opts *StoreBatchOptions) ([]StoreBatchResult, error) {
+	return c.batch(ctx, keyOps("delete", keys), opts)
+}
+
+func keyOps(kind string, keys []StoreItemKey) []storeOp {
+	ops := make([]storeOp, len(keys))
+	for i, key := range keys {
+		ops[i] = storeOp{kind: kind, StoreItemPut: StoreItemPut{Namespace: key.Namespace, Key: key.Key}}
+	}
+	return ops
+}
+
+func (c *StoreClient) batch(ctx context.Context, ops []storeOp, opts *StoreBatchOptions) ([]StoreBatchResult, error) {
+	if opts == nil {
+		opts = &StoreBatchOptions{}
+	}
+
+	results := make([]StoreBatchResult, len(ops))
+	for i, op := range ops {
+		results[i] = StoreBatchResult{Namespace: op.Namespace, Key: op.Key}
+		results[i].Err = validateNamespace(op.Namespace)
+		if op.kind == "put" && results[i].Err == nil {
+			ops[i].TTL, results[i].Err = c.resolveTTL(op.Namespace, op.TTL)
+		}
+	}
+
+	// An invalid operation is the first failure, so nothing is sent.
+	if opts.StopOnError {
+		for _, res := range results {
+			if res.Err != nil {
+				for i := range results {
+					if results[i].Err == nil {
+						results[i].Err = ErrBatchStopped
+					}
+				}
+				return results, res.Err
+			}
+		}
+	}
+
+	if !c.noBatch.Load() {
+		supported, err := c.sendBatches(ctx, ops, results, opts)
+		if supported {
+			return results, err
+		}
+	}
+
+	return results, c.fanOut(ctx, ops, results, opts)
+}
+
+// sendBatches sends the operations to the batch endpoint in chunks. It
+// reports false if the server does not have the endpoint, in which case no
+// operation was applied.
+func (c *StoreClient) sendBatches(ctx context.Context, ops []storeOp, results []StoreBatchResult, opts *StoreBatchOptions) (bool, error) {
+	var firstErr error
+	sent := false
+	for start := 0; start < len(ops); start += storeBatchSize {
+		end := min(start+storeBatchSize, len(ops))
+
+		if firstErr != nil || ctx.Err() != nil {
+			for i := start; i < end; i++ {
+				if results[i].Err == nil {
+					results[i].Err = ErrBatchStopped
+					if ctx.Err() != nil {
+						results[i].Err = ctx.Err()
+					}
+				}
+			}
+			continue
+		}
+
+		payloadOps := []map[string]any{}
+		indexes := []int{}
+		for i := start; i < end; i++ {
+			if results[i].Err != nil {
+				continue
+			}
+			payloadOps = append(payloadOps, batchOpPayload(ops[i], opts))
+			indexes = append(indexes, i)
+		}
+		if len(payloadOps) == 0 {
+			continue
+		}
+
+		resp, err := c.http.Post(ctx, "/store/batch", map[string]any{"ops": payloadOps}, opts.Headers)
+		// Only the first request tells whether the server has the endpoint;
+		// earlier chunks may have been skipped as invalid.
+		var httpErr *http.HTTPError
+		if !sent && errors.As(err, &httpErr) && (httpErr.StatusCode == nethttp.StatusNotFound ||
+			httpErr.StatusCode == nethttp.StatusMethodNotAllowed || httpErr.StatusCode == nethttp.StatusNotImplemented) {
+			c.noBatch.Store(true)
+			return false, nil
+		}
+		sent = true
+
+		var body struct {
+			Results []*schema.Item `json:"results"`
+		}
+		if err == nil {
+			err = json.Unmarshal(resp.Body(), &body)
+		}
+		if err == nil && len(body.Results) != len(indexes) {
+			err = fmt.Errorf("batch returned %d results for %d operations", len(body.Results), len(indexes))
+		}
+
+		for n, i := range indexes {
+			switch {
+			case err != nil:
+				results[i].Err = err
+			case ops[i].kind == "get" && body.Results[n] == nil:
+				results[i].Err = fmt.Errorf("%w: '%s' in namespace '%s'", ErrItemNotFound, ops[i].Key, strings.Join(ops[i].Namespace, "."))
+			case ops[i].kind == "get":
+				results[i].Item = body.Results[n]
+			}
+		}
+		if err != nil && opts.StopOnError {
+			firstErr = err
+		}
+	}
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	return true, firstErr
+}
+
+func batchOpPayload(op storeOp, opts *StoreBatchOptions) map[string]any {
+	payload := map[string]any{
+		"op":        op.kind,
+		"namespace": op.Namespace,
+		"key":       op.Key,
+	}
+	switch op.kind {
+	case "put":
+		payload["value"] = op.Value
+		payload["index"] = op.Index
+		if op.TTL != nil {
+			payload["ttl"] = ttlMinutes(*op.TTL)
+		}
+	case "get":
+		payload["refresh_ttl"] = opts.RefreshTTL
+	}
+
+	payload, ok := removeEmptyFields(payload).(map[string]any)
+	if !ok {
+		fmt.Println("Error: cleanedPayload is not a map[string]any")
+	}
+
+	return payload
+}
+
+// fanOut applies the operations with individual requests. Operations on the
+// same item form a chain that runs sequentially, so their order is kept.
+func (c *StoreClient) fanOut(ctx context.Context, ops []storeOp, results []StoreBatchResult, opts *StoreBatchOptions) error {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+
+	chains := [][]int{}
+	chainOf := map[string]int{}
+	for i, op := range ops {
+		if results[i].Err != nil {
+			continue
+		}
+		id := strings.Join(op.Namespace, ".") + "\x00" + op.Key
+		n, ok := chainOf[id]
+		if !ok {
+			n = len(chains)
+			chainOf[id] = n
+			chains = append(chains, nil)
+		}
+		chains[n] = append(chains[n], i)
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	stopped := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr != nil
+	}
+	sem := make(chan struct{}, concurrency)
+
+	for _, chain := range chains {
+		select {
+		case <-ctx.Done():
+			for _, i := range chain {
+				results[i].Err = ctx.Err()
+			}
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(chain []int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			for _, i := range chain {
+				if ctx.Err() != nil {
+					results[i].Err = ctx.Err()
+					continue
+				}
+				if stopped() {
+					results[i].Err = ErrBatchStopped
+					continue
+				}
+
+				results[i].Item, results[i].Err = c.apply(ctx, ops[i], opts)
+				if results[i].Err != nil && opts.StopOnError && !errors.Is(results[i].Err, ErrItemNotFound) {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = results[i].Err
+					}
+					mu.Unlock()
+				}
+			}
+		}(chain)
+	}
+	wg.Wait()
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	return firstErr
+}
+
+func (c *StoreClient) apply(ctx context.Context, op storeOp, opts *StoreBatchOptions) (*schema.Item, error) {
+	switch op.kind {
+	case "put":
+		return nil, c.PutItem(ctx, op.Namespace, op.Key, op.Value, op.Index, op.TTL, opts.Headers)
+	case "get":
+		return c.GetItem(ctx, op.Namespace, op.Key, opts.RefreshTTL, opts.Headers)
+	default:
+		return nil, c.DeleteItem(ctx, op.Namespace, op.Key, opts.Headers)
+	}
+}
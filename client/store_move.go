@@ -0,0 +1,289 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// MoveNamespaceOptions holds the optional arguments of StoreClient.MoveNamespace
+type MoveNamespaceOptions struct {
+	DeleteSource   bool               // Delete the source items once every namespace has been copied and verified
+	TTL            *time.Duration     // Time to live of every copied item, overriding the remaining TTL of the source items
+	Index          map[string]any     // Index configuration per source namespace, keyed by the "." joined namespace; the server does not return it
+	CheckpointFile string             // File recording progress so an interrupted move can be resumed; empty disables resuming
+	Batch          StoreBatchOptions  // Options of the PutMany and DeleteMany calls
+	Headers        *map[string]string // Headers sent with every request; overrides Batch.Headers
+}
+
+// MoveNamespaceResult summarises a StoreClient.MoveNamespace call
+type MoveNamespaceResult struct {
+	Namespaces int  // Number of source namespaces found
+	Copied     int  // Number of items copied by this call
+	Deleted    int  // Number of source items deleted by this call
+	Resumed    bool // Whether progress was loaded from the checkpoint file
+}
+
+// moveCheckpoint is the progress of a move as stored in the checkpoint file
+type moveCheckpoint struct {
+	From     []string `json:"from"`
+	To       []string `json:"to"`
+	Copied   []string `json:"copied"`   // Source namespaces copied
+	Verified []string `json:"verified"` // Source namespaces found complete at the destination
+	Deleted  []string `json:"deleted"`  // Source namespaces deleted
+}
+
+// MoveNamespace copies every item beneath the from prefix to the same
+// relative namespace beneath to, e.g. ["users", id] to ["tenants", t,
+// "users", id]. Once every namespace is copied, the move is verified by
+// checking that each destination namespace holds every source key; items
+// already at the destination are left alone. Source items are only deleted,
+// if requested, after the verification.
+//
+// Copied items keep the remaining TTL of their source, rounded up to whole
+// minutes, unless opts.TTL overrides it. Items without an expiry time,
+// including every item on servers that do not report one, get the client's
+// default TTL policy.
+//
+// With a checkpoint file, namespaces copied, verified or deleted by an
+// interrupted call are not processed again when it is repeated with the same
+// prefixes. The file is removed
+// once the move completes.
+func (c *StoreClient) MoveNamespace(ctx context.Context, from []string, to []string, opts *MoveNamespaceOptions) (MoveNamespaceResult, error) {
+	if opts == nil {
+		opts = &MoveNamespaceOptions{}
+	}
+	batch := opts.Batch
+	batch.StopOnError = true
+	if opts.Headers != nil {
+		batch.Headers = opts.Headers
+	}
+
+	result := MoveNamespaceResult{}
+	if len(from) == 0 {
+		return result, fmt.Errorf("source namespace prefix is required")
+	}
+	if isNamespacePrefix(from, to) || isNamespacePrefix(to, from) {
+		return result, fmt.Errorf("namespaces '%s' and '%s' overlap", strings.Join(from, "."), strings.Join(to, "."))
+	}
+
+	checkpoint, resumed, err := loadMoveCheckpoint(opts.CheckpointFile, from, to)
+	if err != nil {
+		return result, err
+	}
+	result.Resumed = resumed
+
+	namespaces, items, err := c.itemsUnder(ctx, from, batch.Headers)
+	if err != nil {
+		return result, err
+	}
+	result.Namespaces = len(namespaces)
+
+	for _, namespace := range namespaces {
+		name := strings.Join(namespace, ".")
+		if slices.Contains(checkpoint.Copied, name) {
+			continue
+		}
+
+		copied, err := c.copyNamespace(ctx, namespace, items[name], from, to, opts, &batch)
+		result.Copied += copied
+		if err != nil {
+			return result, fmt.Errorf("copying namespace '%s': %w", name, err)
+		}
+
+		checkpoint.Copied = append(checkpoint.Copied, name)
+		if err := checkpoint.save(opts.CheckpointFile); err != nil {
+			return result, err
+		}
+	}
+
+	if err := c.verifyMove(ctx, namespaces, items, from, to, checkpoint, batch.Headers); err != nil {
+		return result, err
+	}
+	if err := checkpoint.save(opts.CheckpointFile); err != nil {
+		return result, err
+	}
+
+	if opts.DeleteSource {
+		for _, namespace := range namespaces {
+			name := strings.Join(namespace, ".")
+			if slices.Contains(checkpoint.Deleted, name) {
+				continue
+			}
+
+			deleted, err := c.deleteNamespace(ctx, namespace, items[name], &batch)
+			result.Deleted += deleted
+			if err != nil {
+				return result, fmt.Errorf("deleting namespace '%s': %w", name, err)
+			}
+
+			checkpoint.Deleted = append(checkpoint.Deleted, name)
+			if err := checkpoint.save(opts.CheckpointFile); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	if opts.CheckpointFile != "" {
+		if err := os.Remove(opts.CheckpointFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+func (c *StoreClient) copyNamespace(ctx context.Context, namespace []string, items []schema.SearchItem, from []string, to []string, opts *MoveNamespaceOptions, batch *StoreBatchOptions) (int, error) {
+	target, err := remapNamespace(namespace, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	var index *any
+	if config, ok := opts.Index[strings.Join(namespace, ".")]; ok {
+		index = &config
+	}
+
+	now := time.Now()
+	puts := make([]StoreItemPut, len(items))
+	for i, item := range items {
+		ttl := opts.TTL
+		if ttl == nil {
+			ttl = remainingTTL(item.Item, now)
+		}
+		puts[i] = StoreItemPut{Namespace: target, Key: item.Key, Value: item.Value, Index: index, TTL: ttl}
+	}
+
+	copied := 0
+	results, err := c.PutMany(ctx, puts, batch)
+	for _, res := range results {
+		if res.Err == nil {
+			copied++
+		}
+	}
+	return copied, err
+}
+
+// verifyMove checks a fresh listing of the destination against the source
+// items rather than trusting the write results, and records the namespaces
+// found complete in checkpoint. Namespaces verified or deleted before are
+// skipped, since their source may already be partly deleted.
+func (c *StoreClient) verifyMove(ctx context.Context, namespaces [][]string, items map[string][]schema.SearchItem, from []string, to []string, checkpoint *moveCheckpoint, headers *map[string]string) error {
+	pending := [][]string{}
+	for _, namespace := range namespaces {
+		name := strings.Join(namespace, ".")
+		if !slices.Contains(checkpoint.Verified, name) && !slices.Contains(checkpoint.Deleted, name) {
+			pending = append(pending, namespace)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	_, written, err := c.itemsUnder(ctx, to, headers)
+	if err != nil {
+		return err
+	}
+
+	for _, namespace := range pending {
+		name := strings.Join(namespace, ".")
+		target, err := remapNamespace(namespace, from, to)
+		if err != nil {
+			return err
+		}
+
+		keys := map[string]bool{}
+		for _, item := range written[strings.Join(target, ".")] {
+			keys[item.Key] = true
+		}
+		for _, item := range items[name] {
+			if !keys[item.Key] {
+				return fmt.Errorf("verification of namespace '%s' failed: '%s' missing at destination", name, item.Key)
+			}
+		}
+		checkpoint.Verified = append(checkpoint.Verified, name)
+	}
+
+	return nil
+}
+
+// remainingTTL returns the time an item has left before it expires, rounded
+// up to the whole minutes the store accepts, or nil if it does not expire.
+func remainingTTL(item schema.Item, now time.Time) *time.Duration {
+	if item.ExpiresAt == nil {
+		return nil
+	}
+	ttl := max((item.ExpiresAt.Sub(now) + time.Minute - 1).Truncate(time.Minute), time.Minute)
+	return &ttl
+}
+
+// deleteNamespace deletes the source items listed when the move started, so
+// that items written to the source since, which were not copied, are kept.
+func (c *StoreClient) deleteNamespace(ctx context.Context, namespace []string, items []schema.SearchItem, batch *StoreBatchOptions) (int, error) {
+	keys := make([]StoreItemKey, len(items))
+	for i, item := range items {
+		keys[i] = StoreItemKey{Namespace: namespace, Key: item.Key}
+	}
+
+	deleted := 0
+	results, err := c.DeleteMany(ctx, keys, batch)
+	for _, res := range results {
+		if res.Err == nil {
+			deleted++
+		}
+	}
+	return deleted, err
+}
+
+func isNamespacePrefix(prefix []string, namespace []string) bool {
+	return len(prefix) <= len(namespace) && slices.Equal(namespace[:len(prefix)], prefix)
+}
+
+func loadMoveCheckpoint(path string, from []string, to []string) (*moveCheckpoint, bool, error) {
+	checkpoint := &moveCheckpoint{From: from, To: to, Copied: []string{}, Verified: []string{}, Deleted: []string{}}
+	if path == "" {
+		return checkpoint, false, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoint, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var saved moveCheckpoint
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, false, fmt.Errorf("%s: %w", path, err)
+	}
+	if !slices.Equal(saved.From, from) || !slices.Equal(saved.To, to) {
+		return nil, false, fmt.Errorf("%s: checkpoint is for a move from '%s' to '%s'", path, strings.Join(saved.From, "."), strings.Join(saved.To, "."))
+	}
+
+	return &saved, true, nil
+}
+
+func (m *moveCheckpoint) save(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
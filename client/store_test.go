@@ -0,0 +1,531 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	nethttp "net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/http"
+	"github.com/stretchr/testify/assert"
+)
+
+// roundTripFunc serves requests in-process for store client tests.
+type roundTripFunc func(req *nethttp.Request) (int, string)
+
+func (f roundTripFunc) RoundTrip(req *nethttp.Request) (*nethttp.Response, error) {
+	status, body := f(req)
+	return &nethttp.Response{
+		StatusCode: status,
+		Header:     nethttp.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+		Request:    req,
+	}, nil
+}
+
+func newTestStoreClient(handler roundTripFunc) *StoreClient {
+	return NewStoreClient(http.NewHttpClient("http://store.test", nil, 5*time.Second, handler))
+}
+
+func TestStoreGetItem(t *testing.T) {
+	store := newTestStoreClient(func(req *nethttp.Request) (int, string) {
+		switch req.URL.Query().Get("key") {
+		case "m1":
+			return 200, `{"namespace":["users","u1"],"key":"m1","value":{"topic":"billing"},"created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}`
+		case "gone":
+			return 200, `null`
+		}
+		return 404, `{"detail":"Item not found"}`
+	})
+
+	item, err := store.GetItem(context.Background(), []string{"users", "u1"}, "m1", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "billing", item.Value["topic"])
+
+	for _, key := range []string{"missing", "gone"} {
+		item, err = store.GetItem(context.Background(), []string{"users", "u1"}, key, nil, nil)
+		assert.Nil(t, item)
+		assert.ErrorIs(t, err, ErrItemNotFound)
+	}
+}
+
+func TestStoreTTL(t *testing.T) {
+	var mu sync.Mutex
+	sent := map[string]any{}
+	store := newTestStoreClient(func(req *nethttp.Request) (int, string) {
+		var body map[string]any
+		json.NewDecoder(req.Body).Decode(&body)
+		mu.Lock()
+		defer mu.Unlock()
+		sent[body["key"].(string)] = body["ttl"]
+		return 204, ``
+	})
+	ctx := context.Background()
+
+	assert.NoError(t, store.SetDefaultTTL([]string{"sessions"}, time.Hour))
+	assert.NoError(t, store.SetDefaultTTL([]string{"sessions", "guest"}, 5*time.Minute))
+	assert.Error(t, store.SetDefaultTTL(nil, 90*time.Second))
+
+	ttl := 2 * time.Minute
+	assert.NoError(t, store.PutItem(ctx, []string{"sessions", "u1"}, "explicit", map[string]any{}, nil, &ttl, nil))
+	assert.NoError(t, store.PutItem(ctx, []string{"sessions", "u1"}, "default", map[string]any{}, nil, nil, nil))
+	assert.NoError(t, store.PutItem(ctx, []string{"sessions", "guest", "g1"}, "nested", map[string]any{}, nil, nil, nil))
+	assert.NoError(t, store.PutItem(ctx, []string{"users", "u1"}, "none", map[string]any{}, nil, nil, nil))
+
+	bad := 30 * time.Second
+	assert.Error(t, store.PutItem(ctx, []string{"users", "u1"}, "bad", map[string]any{}, nil, &bad, nil))
+
+	assert.Equal(t, map[string]any{"explicit": float64(2), "default": float64(60), "nested": float64(5), "none": nil}, sent)
+}
+
+func TestStoreBatchFallback(t *testing.T) {
+	var mu sync.Mutex
+	batchCalls, puts := 0, []string{}
+	store := newTestStoreClient(func(req *nethttp.Request) (int, string) {
+		mu.Lock()
+		defer mu.Unlock()
+		if req.URL.Path == "/store/batch" {
+			batchCalls++
+			return 404, `{"detail":"Not Found"}`
+		}
+		var body map[string]any
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		if body["key"] == "bad" {
+			return 500, `{"detail":"boom"}`
+		}
+		puts = append(puts, fmt.Sprintf("%s=%v", body["key"], body["value"].(map[string]any)["n"]))
+		return 200, `{}`
+	})
+
+	items := []StoreItemPut{
+		{Namespace: []string{"a"}, Key: "k1", Value: map[string]any{"n": 1}},
+		{Namespace: []string{"a"}, Key: "k2", Value: map[string]any{"n": 2}},
+		{Namespace: []string{"a"}, Key: "k1", Value: map[string]any{"n": 3}},
+		{Namespace: []string{"a"}, Key: "bad", Value: map[string]any{"n": 4}},
+	}
+	results, err := store.PutMany(context.Background(), items, &StoreBatchOptions{Concurrency: 2})
+	assert.NoError(t, err)
+	assert.Len(t, results, 4)
+	assert.Equal(t, "k2", results[1].Key)
+	assert.NoError(t, results[0].Err)
+	assert.Error(t, results[3].Err)
+	assert.Equal(t, 1, batchCalls)
+	// Writes to the same item keep their order.
+	assert.Less(t, slices.Index(puts, "k1=1"), slices.Index(puts, "k1=3"))
+
+	_, err = store.PutMany(context.Background(), items[:1], nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, batchCalls)
+}
+
+func TestStoreBatchFallbackAfterInvalidChunk(t *testing.T) {
+	var mu sync.Mutex
+	batchCalls, puts := 0, 0
+	store := newTestStoreClient(func(req *nethttp.Request) (int, string) {
+		mu.Lock()
+		defer mu.Unlock()
+		if req.URL.Path == "/store/batch" {
+			batchCalls++
+			return 404, `{"detail":"Not Found"}`
+		}
+		puts++
+		return 200, `{}`
+	})
+
+	// The first chunk is all invalid, so the first request sent carries the
+	// second chunk and still detects the missing endpoint.
+	items := make([]StoreItemPut, storeBatchSize+1)
+	for i := range items {
+		items[i] = StoreItemPut{Namespace: []string{"a.b"}, Key: fmt.Sprintf("k%d", i), Value: map[string]any{"n": i}}
+	}
+	items[storeBatchSize].Namespace = []string{"a"}
+
+	results, err := store.PutMany(context.Background(), items, nil)
+	assert.NoError(t, err)
+	assert.ErrorContains(t, results[0].Err, "invalid namespace label")
+	assert.NoError(t, results[storeBatchSize].Err)
+	assert.Equal(t, 1, batchCalls)
+	assert.Equal(t, 1, puts)
+}
+
+func TestStoreBatchFallbackStopOnError(t *testing.T) {
+	var mu sync.Mutex
+	requests := []string{}
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, req *nethttp.Request) {
+		if req.URL.Path == "/store/batch" {
+			w.WriteHeader(nethttp.StatusNotFound)
+			return
+		}
+		var body map[string]any
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		mu.Lock()
+		requests = append(requests, fmt.Sprintf("%s=%v", body["key"], body["value"].(map[string]any)["n"]))
+		mu.Unlock()
+		if body["key"] == "bad" {
+			w.WriteHeader(nethttp.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(nethttp.StatusNoContent)
+	}))
+	defer server.Close()
+	store := NewStoreClient(http.NewHttpClient(server.URL, nil, 5*time.Second, server.Client().Transport))
+	ctx := context.Background()
+
+	// One chain, so the operations run in order and stop at the failure.
+	items := []StoreItemPut{
+		{Namespace: []string{"a"}, Key: "bad", Value: map[string]any{"n": 1}},
+		{Namespace: []string{"a"}, Key: "bad", Value: map[string]any{"n": 2}},
+		{Namespace: []string{"a"}, Key: "bad", Value: map[string]any{"n": 3}},
+	}
+	results, err := store.PutMany(ctx, items, &StoreBatchOptions{StopOnError: true, Concurrency: 1})
+	var httpErr *http.HTTPError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, nethttp.StatusInternalServerError, httpErr.StatusCode)
+	assert.ErrorAs(t, results[0].Err, &httpErr)
+	assert.ErrorIs(t, results[1].Err, ErrBatchStopped)
+	assert.ErrorIs(t, results[2].Err, ErrBatchStopped)
+	assert.Equal(t, []string{"bad=1"}, requests)
+
+	// An invalid operation fails the batch before anything is sent.
+	requests = nil
+	invalid := 30 * time.Second
+	results, err = store.PutMany(ctx, []StoreItemPut{
+		{Namespace: []string{"a"}, Key: "k1", Value: map[string]any{"n": 1}},
+		{Namespace: []string{"a"}, Key: "k2", Value: map[string]any{"n": 2}, TTL: &invalid},
+	}, &StoreBatchOptions{StopOnError: true})
+	assert.ErrorContains(t, err, "whole number of minutes")
+	assert.ErrorIs(t, results[0].Err, ErrBatchStopped)
+	assert.Equal(t, err, results[1].Err)
+	assert.Empty(t, requests)
+}
+
+func TestStoreBatchEndpoint(t *testing.T) {
+	store := newTestStoreClient(func(req *nethttp.Request) (int, string) {
+		return 200, `{"results":[{"namespace":["a"],"key":"k1","value":{"n":1},"created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"},null]}`
+	})
+
+	results, err := store.GetMany(context.Background(), []StoreItemKey{{Namespace: []string{"a"}, Key: "k1"}, {Namespace: []string{"a"}, Key: "k2"}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), results[0].Item.Value["n"])
+	assert.ErrorIs(t, results[1].Err, ErrItemNotFound)
+}
+
+func TestStoreExportStreamsPages(t *testing.T) {
+	var backup bytes.Buffer
+	linesAtSearch := []int{}
+	source := newTestStoreClient(func(req *nethttp.Request) (int, string) {
+		linesAtSearch = append(linesAtSearch, strings.Count(backup.String(), "\n"))
+		var body struct {
+			Offset int `json:"offset"`
+		}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		n := storePageSize
+		if body.Offset > 0 {
+			n = 1
+		}
+		items := make([]string, n)
+		for i := range items {
+			items[i] = fmt.Sprintf(`{"namespace":["users"],"key":"k%d","value":{},"created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}`, body.Offset+i)
+		}
+		return 200, `{"items":[` + strings.Join(items, ",") + `]}`
+	})
+
+	count, err := source.Export(context.Background(), []string{"users"}, &backup, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, storePageSize+1, count)
+	// The first page was written before the second was requested.
+	assert.Equal(t, []int{0, storePageSize}, linesAtSearch)
+}
+
+func TestStoreExportImport(t *testing.T) {
+	searches := 0
+	source := newTestStoreClient(func(req *nethttp.Request) (int, string) {
+		if req.URL.Path != "/store/items/search" {
+			return 404, `{}`
+		}
+		searches++
+		// Searches match nested namespaces too.
+		return 200, `{"items":[
+			{"namespace":["users","u1","notes"],"key":"n1","value":{"text":"hi"},"created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"},
+			{"namespace":["users","u1"],"key":"profile","value":{"name":"Ada"},"created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-02T00:00:00Z"}]}`
+	})
+
+	var backup bytes.Buffer
+	count, err := source.Export(context.Background(), []string{"users"}, &backup, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 2, strings.Count(backup.String(), "\n"))
+	assert.Equal(t, 1, searches)
+
+	var mu sync.Mutex
+	written := []string{}
+	target := newTestStoreClient(func(req *nethttp.Request) (int, string) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case req.URL.Path == "/store/batch":
+			return 404, `{}`
+		case req.Method == nethttp.MethodGet && req.URL.Query().Get("key") == "profile":
+			return 200, `{"namespace":["tenants","t1","users","u1"],"key":"profile","value":{},"created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}`
+		case req.Method == nethttp.MethodGet:
+			return 404, `{}`
+		}
+		var body struct {
+			Namespace []string `json:"namespace"`
+			Key       string   `json:"key"`
+		}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		written = append(written, strings.Join(body.Namespace, ".")+"/"+body.Key)
+		return 200, `{}`
+	})
+
+	result, err := target.Import(context.Background(), &backup, &StoreImportOptions{
+		Policy:     ImportSkipExisting,
+		FromPrefix: []string{"users"},
+		ToPrefix:   []string{"tenants", "t1", "users"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, StoreImportResult{Written: 1, Skipped: 1}, result)
+	assert.Equal(t, []string{"tenants.t1.users.u1.notes/n1"}, written)
+}
+
+// fakeStoreServer is a minimal store backend without the batch endpoint.
+type fakeStoreServer struct {
+	mu    sync.Mutex
+	items map[string]map[string]any // "." joined namespace + "/" + key -> value
+	fail  string                    // key whose writes fail
+	// failDelete is the key whose deletes fail.
+	failDelete string
+}
+
+func (f *fakeStoreServer) serve(req *nethttp.Request) (int, string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body struct {
+		Namespace []string       `json:"namespace"`
+		Key       string         `json:"key"`
+		Value     map[string]any `json:"value"`
+		Limit     int            `json:"limit"`
+		Offset    int            `json:"offset"`
+	}
+	_ = json.NewDecoder(req.Body).Decode(&body)
+
+	switch {
+	case req.URL.Path == "/store/items/search":
+		items := []map[string]any{}
+		for _, id := range slices.Sorted(maps.Keys(f.items)) {
+			namespace := strings.Split(id[:strings.Index(id, "/")], ".")
+			if isNamespacePrefix(body.Namespace, namespace) {
+				items = append(items, map[string]any{"namespace": namespace, "key": id[strings.Index(id, "/")+1:], "value": f.items[id]})
+			}
+		}
+		items = items[min(body.Offset, len(items)):min(body.Offset+body.Limit, len(items))]
+		data, _ := json.Marshal(map[string]any{"items": items})
+		return 200, string(data)
+	case req.URL.Path != "/store/items":
+		return 404, `{}`
+	case req.Method == nethttp.MethodPut:
+		if body.Key == f.fail {
+			return 500, `{"detail":"write failed"}`
+		}
+		f.items[strings.Join(body.Namespace, ".")+"/"+body.Key] = body.Value
+	case req.Method == nethttp.MethodDelete:
+		if body.Key == f.failDelete {
+			return 500, `{"detail":"delete failed"}`
+		}
+		delete(f.items, strings.Join(body.Namespace, ".")+"/"+body.Key)
+	}
+	return 200, `{}`
+}
+
+func TestStoreMoveNamespace(t *testing.T) {
+	server := &fakeStoreServer{
+		items: map[string]map[string]any{
+			"users.u1/profile":    {"name": "Ada"},
+			"users.u1.notes/n1":   {"text": "hi"},
+			"users.u2/settings":   {"theme": "dark"},
+			"sessions.s1/current": {"id": 1},
+		},
+		fail: "settings",
+	}
+	store := newTestStoreClient(server.serve)
+	checkpointFile := filepath.Join(t.TempDir(), "move.json")
+	opts := &MoveNamespaceOptions{DeleteSource: true, CheckpointFile: checkpointFile}
+
+	_, err := store.MoveNamespace(context.Background(), []string{"users"}, []string{"users", "archive"}, opts)
+	assert.Error(t, err)
+
+	result, err := store.MoveNamespace(context.Background(), []string{"users"}, []string{"tenants", "t1", "users"}, opts)
+	assert.Error(t, err)
+	assert.Equal(t, 3, result.Namespaces)
+	assert.FileExists(t, checkpointFile)
+	assert.Contains(t, server.items, "users.u1/profile")
+
+	server.fail = ""
+	result, err = store.MoveNamespace(context.Background(), []string{"users"}, []string{"tenants", "t1", "users"}, opts)
+	assert.NoError(t, err)
+	assert.True(t, result.Resumed)
+	assert.Equal(t, 3, result.Deleted)
+	assert.NoFileExists(t, checkpointFile)
+	assert.Equal(t, []string{
+		"sessions.s1/current",
+		"tenants.t1.users.u1.notes/n1",
+		"tenants.t1.users.u1/profile",
+		"tenants.t1.users.u2/settings",
+	}, slices.Sorted(maps.Keys(server.items)))
+}
+
+// memoryStoreServer serves the store endpoints from a MemoryStore, which
+// reports expiry times, without the batch endpoint.
+func memoryStoreServer(s *MemoryStore) roundTripFunc {
+	return func(req *nethttp.Request) (int, string) {
+		ctx := req.Context()
+		var body struct {
+			Namespace []string       `json:"namespace"`
+			Key       string         `json:"key"`
+			Value     map[string]any `json:"value"`
+			TTL       *int           `json:"ttl"`
+			Limit     *int           `json:"limit"`
+			Offset    *int           `json:"offset"`
+		}
+		if req.Body != nil {
+			_ = json.NewDecoder(req.Body).Decode(&body)
+		}
+
+		var (
+			result any
+			err    error
+		)
+		switch req.Method + " " + req.URL.Path {
+		case "PUT /store/items":
+			var ttl *time.Duration
+			if body.TTL != nil {
+				d := time.Duration(*body.TTL) * time.Minute
+				ttl = &d
+			}
+			err = s.PutItem(ctx, body.Namespace, body.Key, body.Value, nil, ttl, nil)
+		case "GET /store/items":
+			result, err = s.GetItem(ctx, strings.Split(req.URL.Query().Get("namespace"), "."), req.URL.Query().Get("key"), nil, nil)
+		case "DELETE /store/items":
+			err = s.DeleteItem(ctx, body.Namespace, body.Key, nil)
+		case "POST /store/items/search":
+			result, err = s.SearchItems(ctx, body.Namespace, nil, body.Limit, body.Offset, nil, nil, nil)
+		default:
+			return 404, `{"detail":"Not Found"}`
+		}
+
+		if errors.Is(err, ErrItemNotFound) {
+			return 404, `{"detail":"Item not found"}`
+		}
+		if err != nil {
+			return 500, fmt.Sprintf(`{"detail":%q}`, err.Error())
+		}
+		data, _ := json.Marshal(result)
+		return 200, string(data)
+	}
+}
+
+func TestStoreMoveNamespacePreservesTTL(t *testing.T) {
+	ctx := context.Background()
+	memory := NewMemoryStore(nil)
+	store := newTestStoreClient(memoryStoreServer(memory))
+
+	ttl := func(d time.Duration) *time.Duration { return &d }
+	assert.NoError(t, memory.PutItem(ctx, []string{"users", "u1"}, "session", map[string]any{"id": 1}, nil, ttl(10*time.Minute), nil))
+	assert.NoError(t, memory.PutItem(ctx, []string{"users", "u1"}, "profile", map[string]any{"name": "Ada"}, nil, nil, nil))
+	assert.NoError(t, memory.PutItem(ctx, []string{"users", "u1", "notes"}, "n1", map[string]any{"text": "hi"}, nil, ttl(3*time.Minute), nil))
+
+	expiresIn := func(namespace []string, key string) time.Duration {
+		item, err := memory.GetItem(ctx, namespace, key, new(bool), nil)
+		assert.NoError(t, err)
+		if item.ExpiresAt == nil {
+			return 0
+		}
+		return time.Until(*item.ExpiresAt)
+	}
+
+	result, err := store.MoveNamespace(ctx, []string{"users"}, []string{"tenants", "t1", "users"}, &MoveNamespaceOptions{DeleteSource: true})
+	assert.NoError(t, err)
+	assert.Equal(t, MoveNamespaceResult{Namespaces: 2, Copied: 3, Deleted: 3}, result)
+
+	// Remaining TTLs are rounded up to whole minutes.
+	assert.InDelta(t, 10*time.Minute, expiresIn([]string{"tenants", "t1", "users", "u1"}, "session"), float64(5*time.Second))
+	assert.InDelta(t, 3*time.Minute, expiresIn([]string{"tenants", "t1", "users", "u1", "notes"}, "n1"), float64(5*time.Second))
+	assert.Zero(t, expiresIn([]string{"tenants", "t1", "users", "u1"}, "profile"))
+	_, err = memory.GetItem(ctx, []string{"users", "u1"}, "session", nil, nil)
+	assert.ErrorIs(t, err, ErrItemNotFound)
+
+	// An explicit TTL overrides the source TTLs.
+	result, err = store.MoveNamespace(ctx, []string{"tenants", "t1"}, []string{"tenants", "t2"}, &MoveNamespaceOptions{TTL: ttl(time.Hour)})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Copied)
+	assert.InDelta(t, time.Hour, expiresIn([]string{"tenants", "t2", "users", "u1"}, "profile"), float64(5*time.Second))
+	assert.InDelta(t, time.Hour, expiresIn([]string{"tenants", "t2", "users", "u1", "notes"}, "n1"), float64(5*time.Second))
+
+	// Items already at the destination do not fail the verification.
+	assert.NoError(t, memory.PutItem(ctx, []string{"archive", "users", "u1"}, "stale", map[string]any{}, nil, nil, nil))
+	result, err = store.MoveNamespace(ctx, []string{"tenants", "t1"}, []string{"archive"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Copied)
+}
+
+func TestStoreMoveNamespaceResumesPartialDelete(t *testing.T) {
+	server := &fakeStoreServer{
+		items: map[string]map[string]any{
+			"users.u1/a":        {"n": 1},
+			"users.u1/profile":  {"name": "Ada"},
+			"users.u2/settings": {"theme": "dark"},
+		},
+		failDelete: "profile",
+	}
+	var searches atomic.Int32
+	store := newTestStoreClient(func(req *nethttp.Request) (int, string) {
+		if req.URL.Path == "/store/items/search" {
+			searches.Add(1)
+		}
+		return server.serve(req)
+	})
+	checkpointFile := filepath.Join(t.TempDir(), "move.json")
+	opts := &MoveNamespaceOptions{DeleteSource: true, CheckpointFile: checkpointFile, Batch: StoreBatchOptions{Concurrency: 1}}
+	to := []string{"tenants", "t1", "users"}
+
+	// Everything is copied and verified, then deleting users.u1 stops after
+	// its first item.
+	_, err := store.MoveNamespace(context.Background(), []string{"users"}, to, opts)
+	assert.ErrorContains(t, err, "deleting namespace 'users.u1'")
+	assert.NotContains(t, server.items, "users.u1/a")
+	assert.Contains(t, server.items, "users.u1/profile")
+
+	// The destination gains an item the source never had.
+	server.items["tenants.t1.users.u1/b"] = map[string]any{"n": 2}
+
+	server.failDelete = ""
+	searches.Store(0)
+	result, err := store.MoveNamespace(context.Background(), []string{"users"}, to, opts)
+	assert.NoError(t, err)
+	assert.True(t, result.Resumed)
+	assert.Equal(t, 0, result.Copied)
+	assert.Equal(t, 2, result.Deleted)
+	// Only the source is listed; both namespaces were already verified.
+	assert.Equal(t, int32(1), searches.Load())
+	assert.NoFileExists(t, checkpointFile)
+	assert.Equal(t, []string{
+		"tenants.t1.users.u1/a",
+		"tenants.t1.users.u1/b",
+		"tenants.t1.users.u1/profile",
+		"tenants.t1.users.u2/settings",
+	}, slices.Sorted(maps.Keys(server.items)))
+}
@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"sort"
+	"strings"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// NamespaceSeparator separates the levels of a nested subgraph namespace,
+// e.g. "agent|tools".
+const NamespaceSeparator = "|"
+
+// errStopWalk stops a Walk early without reporting an error.
+var errStopWalk = errors.New("stop walk")
+
+// SubgraphNode is a graph or subgraph within a SubgraphTree
+type SubgraphNode struct {
+	Namespace string              // Full namespace, e.g. "agent|tools"; empty for the root graph
+	Name      string              // Last segment of the namespace
+	Path      []string            // Namespace segments from the root
+	Schema    *schema.GraphSchema // The schemas of the subgraph; nil if the server did not report it
+	Parent    *SubgraphNode       // The enclosing graph; nil for the root
+	Children  []*SubgraphNode     // Directly nested subgraphs, sorted by name
+}
+
+// SubgraphTree arranges the flat namespace map returned by
+// AssistantsClient.GetSubgraphs into a tree rooted at the assistant's graph
+type SubgraphTree struct {
+	Root *SubgraphNode
+}
+
+// BuildSubgraphTree builds a tree from a root graph schema and the result of
+// a recursive GetSubgraphs call.
+func BuildSubgraphTree(root *schema.GraphSchema, subgraphs schema.Subgraphs) *SubgraphTree {
+	tree := &SubgraphTree{Root: &SubgraphNode{Schema: root, Path: []string{}}}
+
+	namespaces := make([]string, 0, len(subgraphs))
+	for namespace := range subgraphs {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	for _, namespace := range namespaces {
+		node := tree.ensure(strings.Split(namespace, NamespaceSeparator))
+		graphSchema := subgraphs[namespace]
+		node.Schema = &graphSchema
+	}
+
+	return tree
+}
+
+// GetSubgraphTree fetches the schemas of an assistant's graph and all of its
+// nested subgraphs and arranges them into a tree.
+func (c *AssistantsClient) GetSubgraphTree(ctx context.Context, assistantID string, headers *map[string]string) (*SubgraphTree, error) {
+	root, err := c.GetSchemas(ctx, assistantID, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	recurse := true
+	subgraphs, err := c.GetSubgraphs(ctx, assistantID, nil, &recurse, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return BuildSubgraphTree(&root, subgraphs), nil
+}
+
+// Find returns the node at the given namespace path, or nil if there is none.
+// An empty path returns the root.
+func (t *SubgraphTree) Find(path ...string) *SubgraphNode {
+	node := t.Root
+	for _, name := range path {
+		node = node.Child(name)
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// FindNamespace returns the node with the given "|" separated namespace.
+func (t *SubgraphTree) FindNamespace(namespace string) *SubgraphNode {
+	if namespace == "" {
+		return t.Root
+	}
+	return t.Find(strings.Split(namespace, NamespaceSeparator)...)
+}
+
+// Walk visits every node depth first, parents before children. Returning an
+// error from fn stops the walk and returns that error.
+func (t *SubgraphTree) Walk(fn func(node *SubgraphNode) error) error {
+	var walk func(node *SubgraphNode) error
+	walk = func(node *SubgraphNode) error {
+		if err := fn(node); err != nil {
+			return err
+		}
+		for _, child := range node.Children {
+			if err := walk(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(t.Root)
+}
+
+// All iterates over every node depth first, parents before children.
+func (t *SubgraphTree) All() iter.Seq[*SubgraphNode] {
+	return func(yield func(*SubgraphNode) bool) {
+		_ = t.Walk(func(node *SubgraphNode) error {
+			if !yield(node) {
+				return errStopWalk
+			}
+			return nil
+		})
+	}
+}
+
+// Child returns the directly nested subgraph with the given name, or nil.
+func (n *SubgraphNode) Child(name string) *SubgraphNode {
+	for _, child := range n.Children {
+		if child.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+func (t *SubgraphTree) ensure(path []string) *SubgraphNode {
+	node := t.Root
+	for i, name := range path {
+		child := node.Child(name)
+		if child == nil {
+			child = &SubgraphNode{
+				Namespace: strings.Join(path[:i+1], NamespaceSeparator),
+				Name:      name,
+				Path:      append([]string{}, path[:i+1]...),
+				Parent:    node,
+			}
+			node.Children = append(node.Children, child)
+			sort.Slice(node.Children, func(a, b int) bool { return node.Children[a].Name < node.Children[b].Name })
+		}
+		node = child
+	}
+	return node
+}
+
+// SubgraphState returns the nested state of the subgraph at path within a
+// state fetched with subgraphs enabled. Each path segment names the task
+// (node) running the next subgraph.
+func SubgraphState(state schema.ThreadState, path ...string) (schema.ThreadState, bool) {
+	current := state
+	for _, name := range path {
+		found := false
+		for _, task := range current.Tasks {
+			if task.Name == name && task.State != nil {
+				current = *task.State
+				found = true
+				break
+			}
+		}
+		if !found {
+			return schema.ThreadState{}, false
+		}
+	}
+	return current, true
+}
+
+// GetSubgraphState fetches the current state of a thread, including subgraph
+// states, and returns the state of the subgraph at the given namespace path.
+func (c *ThreadsClient) GetSubgraphState(ctx context.Context, threadID string, path []string, headers *map[string]string) (schema.ThreadState, error) {
+	subgraphs := true
+	state, err := c.GetState(ctx, threadID, nil, nil, &subgraphs, headers)
+	if err != nil {
+		return schema.ThreadState{}, err
+	}
+
+	nested, ok := SubgraphState(state, path...)
+	if !ok {
+		return schema.ThreadState{}, fmt.Errorf("thread '%s' has no running subgraph at '%s'", threadID, strings.Join(path, NamespaceSeparator))
+	}
+
+	return nested, nil
+}
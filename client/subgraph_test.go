@@ -0,0 +1,68 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSubgraphTree(t *testing.T) {
+	tree := BuildSubgraphTree(&schema.GraphSchema{GraphID: "main"}, schema.Subgraphs{
+		"agent|tools":  {GraphID: "tools"},
+		"agent":        {GraphID: "agent"},
+		"review|judge": {GraphID: "judge"},
+	})
+
+	assert.Equal(t, "main", tree.Root.Schema.GraphID)
+
+	namespaces := []string{}
+	for node := range tree.All() {
+		namespaces = append(namespaces, node.Namespace)
+	}
+	assert.Equal(t, []string{"", "agent", "agent|tools", "review", "review|judge"}, namespaces)
+
+	tools := tree.Find("agent", "tools")
+	assert.Equal(t, "tools", tools.Schema.GraphID)
+	assert.Equal(t, []string{"agent", "tools"}, tools.Path)
+	assert.Same(t, tree.Find("agent"), tools.Parent)
+	assert.Same(t, tools, tree.FindNamespace("agent|tools"))
+	assert.Same(t, tree.Root, tree.FindNamespace(""))
+	assert.Nil(t, tree.Find("agent", "missing"))
+
+	// Intermediate namespaces the server did not report have no schema.
+	assert.Nil(t, tree.FindNamespace("review").Schema)
+
+	visited := 0
+	for range tree.All() {
+		visited++
+		if visited == 2 {
+			break
+		}
+	}
+	assert.Equal(t, 2, visited)
+}
+
+func TestSubgraphState(t *testing.T) {
+	inner := schema.ThreadState{Values: map[string]any{"depth": 2}}
+	middle := schema.ThreadState{Values: map[string]any{"depth": 1}, Tasks: []schema.ThreadTask{
+		{Name: "tools", State: &inner},
+	}}
+	state := schema.ThreadState{Tasks: []schema.ThreadTask{
+		{Name: "other"},
+		{Name: "agent", State: &middle},
+	}}
+
+	nested, ok := SubgraphState(state, "agent", "tools")
+	assert.True(t, ok)
+	assert.Equal(t, inner, nested)
+
+	nested, ok = SubgraphState(state)
+	assert.True(t, ok)
+	assert.Equal(t, state, nested)
+
+	_, ok = SubgraphState(state, "other")
+	assert.False(t, ok)
+	_, ok = SubgraphState(state, "agent", "missing")
+	assert.False(t, ok)
+}
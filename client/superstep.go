@@ -0,0 +1,127 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// InputNode is the node to attribute an update to when it seeds the state as
+// graph input rather than as the output of a node.
+const InputNode = "__input__"
+
+// SuperstepsBuilder assembles the supersteps used to seed a thread in
+// ThreadsClient.Create, e.g. when replaying an existing conversation log.
+type SuperstepsBuilder struct {
+	supersteps []schema.Superstep
+}
+
+func NewSuperstepsBuilder() *SuperstepsBuilder {
+	return &SuperstepsBuilder{supersteps: []schema.Superstep{}}
+}
+
+// Superstep appends a superstep made of the given updates.
+func (b *SuperstepsBuilder) Superstep(updates ...schema.StateUpdate) *SuperstepsBuilder {
+	b.supersteps = append(b.supersteps, schema.Superstep{Updates: updates})
+	return b
+}
+
+// Update appends a superstep with a single update attributed to asNode.
+func (b *SuperstepsBuilder) Update(values any, asNode string) *SuperstepsBuilder {
+	return b.Superstep(schema.StateUpdate{Values: values, AsNode: asNode})
+}
+
+// Command appends a superstep with a single command attributed to asNode.
+func (b *SuperstepsBuilder) Command(command schema.Command, asNode string) *SuperstepsBuilder {
+	return b.Superstep(schema.StateUpdate{AsNode: asNode, Command: &command})
+}
+
+// Input appends a superstep that writes values as the graph input.
+func (b *SuperstepsBuilder) Input(values any) *SuperstepsBuilder {
+	return b.Update(values, InputNode)
+}
+
+// Messages appends a superstep in which asNode writes messages to the
+// "messages" key of the state.
+func (b *SuperstepsBuilder) Messages(asNode string, messages ...any) *SuperstepsBuilder {
+	return b.Update(map[string]any{"messages": messages}, asNode)
+}
+
+// Build returns the supersteps assembled so far.
+func (b *SuperstepsBuilder) Build() []schema.Superstep {
+	supersteps := make([]schema.Superstep, len(b.supersteps))
+	copy(supersteps, b.supersteps)
+	return supersteps
+}
+
+// SuperstepsFromMaps converts supersteps in their untyped map form
+// ({"updates": [{"values": ..., "as_node": ..., "command": ...}]}), as taken
+// by ThreadsClient.CreateWithSuperstepMaps.
+//
+// Deprecated: build supersteps with SuperstepsBuilder or schema.Superstep.
+func SuperstepsFromMaps(supersteps []any) ([]schema.Superstep, error) {
+	result := make([]schema.Superstep, 0, len(supersteps))
+	for _, s := range supersteps {
+		sMap, ok := s.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("each superstep must be a map, got %T", s)
+		}
+		updatesRaw, ok := sMap["updates"]
+		if !ok {
+			return nil, fmt.Errorf("superstep missing 'updates' key")
+		}
+		updatesSlice, ok := updatesRaw.([]any)
+		if !ok {
+			return nil, fmt.Errorf("'updates' must be a slice, got %T", updatesRaw)
+		}
+
+		superstep := schema.Superstep{Updates: make([]schema.StateUpdate, 0, len(updatesSlice))}
+		for _, u := range updatesSlice {
+			uMap, ok := u.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("each update must be a map, got %T", u)
+			}
+			update := schema.StateUpdate{Values: uMap["values"]}
+			if asNode, ok := uMap["as_node"]; ok && asNode != nil {
+				asNodeStr, ok := asNode.(string)
+				if !ok {
+					return nil, fmt.Errorf("'as_node' must be a string, got %T", asNode)
+				}
+				update.AsNode = asNodeStr
+			}
+			if cmd, ok := uMap["command"]; ok && cmd != nil {
+				switch c := cmd.(type) {
+				case schema.Command:
+					update.Command = &c
+				case *schema.Command:
+					update.Command = c
+				case map[string]any:
+					update.Command = &schema.Command{Goto: c["goto"], Resume: c["resume"]}
+					if u, ok := c["update"].(map[string]any); ok {
+						update.Command.Update = u
+					}
+				default:
+					return nil, fmt.Errorf("'command' must be a map or schema.Command, got %T", cmd)
+				}
+			}
+			superstep.Updates = append(superstep.Updates, update)
+		}
+		result = append(result, superstep)
+	}
+
+	return result, nil
+}
+
+func validateSupersteps(supersteps []schema.Superstep) error {
+	for i, superstep := range supersteps {
+		if len(superstep.Updates) == 0 {
+			return fmt.Errorf("superstep %d has no updates", i)
+		}
+		for j, update := range superstep.Updates {
+			if update.AsNode == "" {
+				return fmt.Errorf("superstep %d update %d missing 'as_node'", i, j)
+			}
+		}
+	}
+	return nil
+}
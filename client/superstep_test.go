@@ -0,0 +1,103 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuperstepsBuilder(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *SuperstepsBuilder
+		want    string
+	}{
+		{
+			name:    "input",
+			builder: NewSuperstepsBuilder().Input(map[string]any{"question": "hi"}),
+			want:    `[{"updates":[{"values":{"question":"hi"},"as_node":"__input__"}]}]`,
+		},
+		{
+			name:    "messages",
+			builder: NewSuperstepsBuilder().Messages("agent", map[string]any{"role": "ai", "content": "hello"}),
+			want:    `[{"updates":[{"values":{"messages":[{"content":"hello","role":"ai"}]},"as_node":"agent"}]}]`,
+		},
+		{
+			name:    "command",
+			builder: NewSuperstepsBuilder().Command(schema.Command{Resume: "yes"}, "review"),
+			want:    `[{"updates":[{"values":null,"as_node":"review","command":{"resume":"yes"}}]}]`,
+		},
+		{
+			name: "conversation log",
+			builder: NewSuperstepsBuilder().
+				Input(map[string]any{"messages": []any{"hi"}}).
+				Superstep(
+					schema.StateUpdate{Values: map[string]any{"a": 1}, AsNode: "left"},
+					schema.StateUpdate{Values: map[string]any{"b": 2}, AsNode: "right"},
+				),
+			want: `[{"updates":[{"values":{"messages":["hi"]},"as_node":"__input__"}]},` +
+				`{"updates":[{"values":{"a":1},"as_node":"left"},{"values":{"b":2},"as_node":"right"}]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			supersteps := tt.builder.Build()
+			assert.NoError(t, validateSupersteps(supersteps))
+			data, err := json.Marshal(supersteps)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(data))
+		})
+	}
+}
+
+func TestSuperstepsFromMaps(t *testing.T) {
+	supersteps, err := SuperstepsFromMaps([]any{
+		map[string]any{"updates": []any{
+			map[string]any{"values": map[string]any{"a": 1}, "as_node": "agent"},
+			map[string]any{"as_node": "review", "command": map[string]any{"goto": "agent", "update": map[string]any{"b": 2}}},
+		}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []schema.Superstep{{Updates: []schema.StateUpdate{
+		{Values: map[string]any{"a": 1}, AsNode: "agent"},
+		{AsNode: "review", Command: &schema.Command{Goto: "agent", Update: map[string]any{"b": 2}}},
+	}}}, supersteps)
+
+	tests := []struct {
+		name       string
+		supersteps []any
+		err        string
+	}{
+		{"not a map", []any{"x"}, "each superstep must be a map"},
+		{"missing updates", []any{map[string]any{}}, "superstep missing 'updates' key"},
+		{"updates not a slice", []any{map[string]any{"updates": "x"}}, "'updates' must be a slice"},
+		{"update not a map", []any{map[string]any{"updates": []any{1}}}, "each update must be a map"},
+		{"as_node not a string", []any{map[string]any{"updates": []any{map[string]any{"as_node": 1}}}}, "'as_node' must be a string"},
+		{"bad command", []any{map[string]any{"updates": []any{map[string]any{"as_node": "a", "command": 1}}}}, "'command' must be a map"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SuperstepsFromMaps(tt.supersteps)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestValidateSupersteps(t *testing.T) {
+	tests := []struct {
+		name       string
+		supersteps []schema.Superstep
+		err        string
+	}{
+		{"empty updates", []schema.Superstep{{Updates: []schema.StateUpdate{{AsNode: "a"}}}, {}}, "superstep 1 has no updates"},
+		{"missing as_node", []schema.Superstep{{Updates: []schema.StateUpdate{{AsNode: "a"}, {Values: 1}}}}, "superstep 0 update 1 missing 'as_node'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, validateSupersteps(tt.supersteps), tt.err)
+		})
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"sort"
+	"strings"
 
 	"github.com/KhanhD1nh/langgraph-sdk-go/http"
 	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
@@ -32,7 +35,7 @@ func (c *ThreadsClient) Get(ctx context.Context, threadID string, headers *map[s
 	return thread, nil
 }
 
-func (c *ThreadsClient) Create(ctx context.Context, metadata *schema.Json, threadID *string, ifExists *schema.OnConflictBehavior, supersteps *[]any, graphID *string, headers *map[string]string) (schema.Thread, error) {
+func (c *ThreadsClient) Create(ctx context.Context, metadata *schema.Json, threadID *string, ifExists *schema.OnConflictBehavior, supersteps *[]schema.Superstep, graphID *string, headers *map[string]string) (schema.Thread, error) {
 	payload := map[string]any{}
 	if metadata != nil {
 		payload["metadata"] = *metadata
@@ -44,41 +47,10 @@ func (c *ThreadsClient) Create(ctx context.Context, metadata *schema.Json, threa
 		payload["if_exists"] = *ifExists
 	}
 	if supersteps != nil {
-		superstepsSlice := *supersteps
-		var superstepsPayload []map[string]any
-		for _, s := range superstepsSlice {
-			sMap, ok := s.(map[string]any)
-			if !ok {
-				return schema.Thread{}, fmt.Errorf("each superstep must be a map, got %T", s)
-			}
-			updatesRaw, ok := sMap["updates"]
-			if !ok {
-				return schema.Thread{}, fmt.Errorf("superstep missing 'updates' key")
-			}
-			updatesSlice, ok := updatesRaw.([]any)
-			if !ok {
-				return schema.Thread{}, fmt.Errorf("'updates' must be a slice, got %T", updatesRaw)
-			}
-			var updatesPayload []map[string]any
-			for _, u := range updatesSlice {
-				uMap, ok := u.(map[string]any)
-				if !ok {
-					return schema.Thread{}, fmt.Errorf("each update must be a map, got %T", u)
-				}
-				updateObj := map[string]any{
-					"values":  uMap["values"],
-					"as_node": uMap["as_node"],
-				}
-				if cmd, ok := uMap["command"]; ok {
-					updateObj["command"] = cmd
-				}
-				updatesPayload = append(updatesPayload, updateObj)
-			}
-			superstepsPayload = append(superstepsPayload, map[string]any{
-				"updates": updatesPayload,
-			})
+		if err := validateSupersteps(*supersteps); err != nil {
+			return schema.Thread{}, err
 		}
-		payload["supersteps"] = superstepsPayload
+		payload["supersteps"] = *supersteps
 	}
 	if graphID != nil {
 		payload["graph_id"] = *graphID
@@ -103,6 +75,23 @@ func (c *ThreadsClient) Create(ctx context.Context, metadata *schema.Json, threa
 	return thread, nil
 }
 
+// CreateWithSuperstepMaps creates a thread seeded with supersteps in their
+// untyped map form, as accepted by Create before it took schema.Superstep.
+//
+// Deprecated: use Create with supersteps built by SuperstepsBuilder.
+func (c *ThreadsClient) CreateWithSuperstepMaps(ctx context.Context, metadata *schema.Json, threadID *string, ifExists *schema.OnConflictBehavior, supersteps *[]any, graphID *string, headers *map[string]string) (schema.Thread, error) {
+	var typed *[]schema.Superstep
+	if supersteps != nil {
+		converted, err := SuperstepsFromMaps(*supersteps)
+		if err != nil {
+			return schema.Thread{}, err
+		}
+		typed = &converted
+	}
+
+	return c.Create(ctx, metadata, threadID, ifExists, typed, graphID, headers)
+}
+
 func (c *ThreadsClient) Update(ctx context.Context, threadID string, metadata *schema.Json, headers *map[string]string) (schema.Thread, error) {
 	payload := map[string]any{}
 	if metadata != nil {
@@ -225,7 +214,7 @@ func (c *ThreadsClient) GetState(ctx context.Context, threadID string, checkPoin
 
 		return threadState, nil
 	} else if checkPointID != nil {
-		resp, err := c.http.Get(ctx, fmt.Sprintf("/threads/%s/state/%s", threadID, *checkPointID), nil, headers)
+		resp, err := c.http.Get(ctx, fmt.Sprintf("/threads/%s/state/%s", threadID, *checkPointID), subgraphsParams(*subgraphs), headers)
 		if err != nil {
 			return schema.ThreadState{}, err
 		}
@@ -238,7 +227,7 @@ func (c *ThreadsClient) GetState(ctx context.Context, threadID string, checkPoin
 
 		return threadState, nil
 	} else {
-		resp, err := c.http.Get(ctx, fmt.Sprintf("/threads/%s/state", threadID), nil, headers)
+		resp, err := c.http.Get(ctx, fmt.Sprintf("/threads/%s/state", threadID), subgraphsParams(*subgraphs), headers)
 		if err != nil {
 			return schema.ThreadState{}, err
 		}
@@ -254,6 +243,13 @@ func (c *ThreadsClient) GetState(ctx context.Context, threadID string, checkPoin
 	}
 }
 
+func subgraphsParams(subgraphs bool) url.Values {
+	if !subgraphs {
+		return nil
+	}
+	return url.Values{"subgraphs": []string{"true"}}
+}
+
 func (c *ThreadsClient) UpdateState(ctx context.Context, threadID string, values *any, asNode *string, checkPoint *schema.Checkpoint, checkPointID *string, headers *map[string]string) (schema.ThreadUpdateStateResponse, error) {
 	payload := map[string]any{
 		"values": *values,
@@ -324,3 +320,90 @@ func (c *ThreadsClient) GetHistory(ctx context.Context, threadID string, limit *
 
 	return threadStates, nil
 }
+
+// Metadata keys used to record the lineage of a forked thread.
+const (
+	ForkedFromThreadKey     = "forked_from_thread"
+	ForkedFromCheckpointKey = "forked_from_checkpoint"
+)
+
+// Fork creates a new thread seeded with the state of threadID at checkpointID.
+// The new thread inherits the source thread's metadata, overlaid with metadata,
+// and records its lineage under ForkedFromThreadKey and ForkedFromCheckpointKey.
+//
+// The seeded state is attributed to asNode, which decides the node that runs
+// next on the fork. If asNode is nil, it is taken from the checkpoint: the
+// node that wrote it, or the graph input for an input checkpoint. Checkpoints
+// written by several nodes are ambiguous and require asNode.
+func (c *ThreadsClient) Fork(ctx context.Context, threadID string, checkpointID string, metadata *schema.Json, asNode *string, headers *map[string]string) (schema.Thread, error) {
+	source, err := c.Get(ctx, threadID, headers)
+	if err != nil {
+		return schema.Thread{}, err
+	}
+
+	state, err := c.GetState(ctx, threadID, nil, &checkpointID, nil, headers)
+	if err != nil {
+		return schema.Thread{}, err
+	}
+
+	node, err := forkAsNode(state, asNode)
+	if err != nil {
+		return schema.Thread{}, err
+	}
+
+	forkMetadata := schema.Json{}
+	for k, v := range source.Metadata {
+		forkMetadata[k] = v
+	}
+	if metadata != nil {
+		for k, v := range *metadata {
+			forkMetadata[k] = v
+		}
+	}
+	forkMetadata[ForkedFromThreadKey] = threadID
+	forkMetadata[ForkedFromCheckpointKey] = checkpointID
+
+	var graphID *string
+	if id, ok := source.Metadata["graph_id"].(string); ok && id != "" {
+		graphID = &id
+	}
+
+	supersteps := NewSuperstepsBuilder().Update(state.Values, node).Build()
+
+	return c.Create(ctx, &forkMetadata, nil, nil, &supersteps, graphID, headers)
+}
+
+// ListForks returns the threads that were forked from threadID.
+func (c *ThreadsClient) ListForks(ctx context.Context, threadID string, limit *int, offset *int, headers *map[string]string) ([]schema.Thread, error) {
+	metadata := schema.Json{ForkedFromThreadKey: threadID}
+	sortBy := schema.ThreadSortByCreatedAt
+	sortOrder := schema.SortOrderDesc
+
+	return c.Search(ctx, &metadata, nil, nil, limit, offset, &sortBy, &sortOrder, headers)
+}
+
+// forkAsNode picks the node the seeded state is attributed to: asNode if set,
+// otherwise the single node that wrote the checkpoint, or the graph input if
+// no node did.
+func forkAsNode(state schema.ThreadState, asNode *string) (string, error) {
+	if asNode != nil {
+		return *asNode, nil
+	}
+
+	writes, _ := state.Metadata["writes"].(map[string]any)
+	nodes := make([]string, 0, len(writes))
+	for node := range writes {
+		if node != StartNode {
+			nodes = append(nodes, node)
+		}
+	}
+	switch len(nodes) {
+	case 0:
+		return InputNode, nil
+	case 1:
+		return nodes[0], nil
+	}
+
+	sort.Strings(nodes)
+	return "", fmt.Errorf("checkpoint was written by several nodes (%s): pass the node to fork as", strings.Join(nodes, ", "))
+}
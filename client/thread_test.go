@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/http"
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThreadFork(t *testing.T) {
+	writes := map[string]any{"agent": map[string]any{}}
+	var created map[string]any
+	threads := NewThreadsClient(http.NewHttpClient("http://threads.test", nil, 5*time.Second, roundTripFunc(func(req *nethttp.Request) (int, string) {
+		switch req.Method + " " + req.URL.Path {
+		case "GET /threads/t1":
+			return 200, `{"thread_id":"t1","metadata":{"graph_id":"agent","owner":"a"}}`
+		case "GET /threads/t1/state/c1":
+			state, _ := json.Marshal(map[string]any{"values": map[string]any{"step": 2}, "metadata": map[string]any{"writes": writes}})
+			return 200, string(state)
+		case "POST /threads":
+			body, _ := io.ReadAll(req.Body)
+			created = nil
+			json.Unmarshal(body, &created)
+			return 200, `{"thread_id":"t2"}`
+		}
+		return 404, `{"detail":"not found"}`
+	})))
+	ctx := context.Background()
+
+	asNode := func() any {
+		return created["supersteps"].([]any)[0].(map[string]any)["updates"].([]any)[0].(map[string]any)["as_node"]
+	}
+
+	metadata := schema.Json{"owner": "b"}
+	thread, err := threads.Fork(ctx, "t1", "c1", &metadata, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "t2", thread.ThreadID)
+	assert.Equal(t, "agent", created["graph_id"])
+	assert.Equal(t, map[string]any{"graph_id": "agent", "owner": "b", ForkedFromThreadKey: "t1", ForkedFromCheckpointKey: "c1"}, created["metadata"])
+	assert.Equal(t, "agent", asNode())
+
+	writes = map[string]any{"__start__": map[string]any{}}
+	_, err = threads.Fork(ctx, "t1", "c1", nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, InputNode, asNode())
+
+	writes = map[string]any{"tools": map[string]any{}, "agent": map[string]any{}}
+	created = nil
+	_, err = threads.Fork(ctx, "t1", "c1", nil, nil, nil)
+	assert.ErrorContains(t, err, "several nodes (agent, tools)")
+	assert.Nil(t, created)
+
+	node := "tools"
+	_, err = threads.Fork(ctx, "t1", "c1", nil, &node, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "tools", asNode())
+}
+
+func TestThreadListForks(t *testing.T) {
+	var search map[string]any
+	threads := NewThreadsClient(http.NewHttpClient("http://threads.test", nil, 5*time.Second, roundTripFunc(func(req *nethttp.Request) (int, string) {
+		json.NewDecoder(req.Body).Decode(&search)
+		return 200, `[{"thread_id":"t2"}]`
+	})))
+
+	limit := 5
+	forks, err := threads.ListForks(context.Background(), "t1", &limit, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, forks, 1)
+	assert.Equal(t, map[string]any{ForkedFromThreadKey: "t1"}, search["metadata"])
+	assert.Equal(t, float64(5), search["limit"])
+	assert.Equal(t, "created_at", search["sort_by"])
+	assert.Equal(t, "desc", search["sort_order"])
+}
+
+func TestThreadCreateWithSuperstepMaps(t *testing.T) {
+	var created map[string]any
+	threads := NewThreadsClient(http.NewHttpClient("http://threads.test", nil, 5*time.Second, roundTripFunc(func(req *nethttp.Request) (int, string) {
+		json.NewDecoder(req.Body).Decode(&created)
+		return 200, `{"thread_id":"t1"}`
+	})))
+
+	supersteps := []any{map[string]any{"updates": []any{map[string]any{"values": map[string]any{"a": 1}, "as_node": "agent"}}}}
+	thread, err := threads.CreateWithSuperstepMaps(context.Background(), nil, nil, nil, &supersteps, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "t1", thread.ThreadID)
+	assert.Equal(t, []any{map[string]any{"updates": []any{map[string]any{"values": map[string]any{"a": float64(1)}, "as_node": "agent"}}}}, created["supersteps"])
+
+	created = nil
+	invalid := []any{map[string]any{"updates": []any{map[string]any{"values": 1}}}}
+	_, err = threads.CreateWithSuperstepMaps(context.Background(), nil, nil, nil, &invalid, nil, nil)
+	assert.ErrorContains(t, err, "missing 'as_node'")
+	assert.Nil(t, created)
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// ItemMeta is the store metadata returned alongside a decoded TypedStore value
+type ItemMeta struct {
+	Namespace []string  // The namespace of the item
+	Key       string    // The key of the item within its namespace
+	CreatedAt time.Time // The timestamp when the item was created
+	UpdatedAt time.Time // The timestamp when the item was last updated
+	Score     *float64  // Relevance score; only set on search results for a query
+}
+
+// TypedItem is a store item with its value decoded into T
+type TypedItem[T any] struct {
+	ItemMeta
+	Value T
+}
+
+// PutOptions holds the optional arguments of TypedStore.Put
+type PutOptions struct {
+	Index   *any               // Fields to index for search; false disables indexing
+	TTL     *time.Duration     // Time to live; nil applies the store's default TTL policy, if any
+	Headers *map[string]string // Headers sent with the request
+}
+
+// TypedStore stores values of type T in a namespace, encoding them to and
+// from the JSON object values of the underlying store. T must encode to a
+// JSON object, e.g. a struct or a map with string keys.
+type TypedStore[T any] struct {
+	store     Store
+	namespace []string
+	headers   *map[string]string
+	pageSize  int
+}
+
+// NewTypedStore returns a TypedStore for the items in namespace.
+func NewTypedStore[T any](store Store, namespace []string) *TypedStore[T] {
+	return &TypedStore[T]{
+		store:     store,
+		namespace: append([]string{}, namespace...),
+		pageSize:  100,
+	}
+}
+
+// WithHeaders returns a copy of the store that sends headers with every
+// request.
+func (s *TypedStore[T]) WithHeaders(headers *map[string]string) *TypedStore[T] {
+	copied := *s
+	copied.headers = headers
+	return &copied
+}
+
+// Namespace returns the namespace of the store.
+func (s *TypedStore[T]) Namespace() []string {
+	return append([]string{}, s.namespace...)
+}
+
+// Put encodes value and stores it under key.
+func (s *TypedStore[T]) Put(ctx context.Context, key string, value T, opts *PutOptions) error {
+	encoded, err := encodeItemValue(value)
+	if err != nil {
+		return fmt.Errorf("encoding item '%s': %w", key, err)
+	}
+
+	var index *any
+	var ttl *time.Duration
+	headers := s.headers
+	if opts != nil {
+		index, ttl = opts.Index, opts.TTL
+		if opts.Headers != nil {
+			headers = opts.Headers
+		}
+	}
+
+	return s.store.PutItem(ctx, s.namespace, key, encoded, index, ttl, headers)
+}
+
+// Get fetches and decodes the item stored under key. It returns
+// ErrItemNotFound if there is none.
+func (s *TypedStore[T]) Get(ctx context.Context, key string) (T, *ItemMeta, error) {
+	var zero T
+
+	item, err := s.store.GetItem(ctx, s.namespace, key, nil, s.headers)
+	if err != nil {
+		return zero, nil, err
+	}
+
+	typed, err := decodeItem[T](schema.SearchItem{Item: *item})
+	if err != nil {
+		return zero, nil, err
+	}
+
+	return typed.Value, &typed.ItemMeta, nil
+}
+
+// Delete removes the item stored under key.
+func (s *TypedStore[T]) Delete(ctx context.Context, key string) error {
+	return s.store.DeleteItem(ctx, s.namespace, key, s.headers)
+}
+
+// Search iterates over the items in the store's namespace, and namespaces
+// nested beneath it, that match filter. Results are fetched page by page as
+// the iteration proceeds. A decode or request error is yielded with a zero
+// item; iteration stops after a request error.
+func (s *TypedStore[T]) Search(ctx context.Context, filter *map[string]any) iter.Seq2[TypedItem[T], error] {
+	return func(yield func(TypedItem[T], error) bool) {
+		offset := 0
+		for {
+			limit := s.pageSize
+			page, err := s.store.SearchItems(ctx, s.namespace, filter, &limit, &offset, nil, nil, s.headers)
+			if err != nil {
+				yield(TypedItem[T]{}, err)
+				return
+			}
+
+			for _, item := range page.Items {
+				if !yield(decodeItem[T](item)) {
+					return
+				}
+			}
+
+			if len(page.Items) < limit {
+				return
+			}
+			offset += len(page.Items)
+		}
+	}
+}
+
+func decodeItem[T any](item schema.SearchItem) (TypedItem[T], error) {
+	typed := TypedItem[T]{
+		ItemMeta: ItemMeta{
+			Namespace: item.Namespace,
+			Key:       item.Key,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+			Score:     item.Score,
+		},
+	}
+
+	if err := remarshal(item.Value, &typed.Value); err != nil {
+		return TypedItem[T]{}, fmt.Errorf("decoding item '%s': %w", item.Key, err)
+	}
+
+	return typed, nil
+}
+
+func encodeItemValue(value any) (map[string]any, error) {
+	var encoded map[string]any
+	if err := remarshal(value, &encoded); err != nil {
+		return nil, err
+	}
+	if encoded == nil {
+		return nil, fmt.Errorf("value must encode to a JSON object")
+	}
+	return encoded, nil
+}
+
+// remarshal converts in to out through its JSON encoding.
+func remarshal(in any, out any) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type testMemory struct {
+	Topic string   `json:"topic"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+func TestTypedItemRoundTrip(t *testing.T) {
+	encoded, err := encodeItemValue(testMemory{Topic: "billing", Tags: []string{"invoice"}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"topic": "billing", "tags": []any{"invoice"}}, encoded)
+
+	_, err = encodeItemValue("not an object")
+	assert.Error(t, err)
+
+	score := 0.75
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	item, err := decodeItem[testMemory](schema.SearchItem{
+		Item: schema.Item{
+			Namespace: []string{"users", "u1"},
+			Key:       "m1",
+			Value:     encoded,
+			CreatedAt: created,
+			UpdatedAt: created,
+		},
+		Score: &score,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, testMemory{Topic: "billing", Tags: []string{"invoice"}}, item.Value)
+	assert.Equal(t, []string{"users", "u1"}, item.Namespace)
+	assert.Equal(t, &score, item.Score)
+
+	_, err = decodeItem[testMemory](schema.SearchItem{Item: schema.Item{Key: "bad", Value: map[string]any{"topic": 1}}})
+	assert.Error(t, err)
+}
+
+// searchCountingStore counts the search requests made to a MemoryStore.
+type searchCountingStore struct {
+	*MemoryStore
+	searches int
+}
+
+func (s *searchCountingStore) SearchItems(ctx context.Context, namespace []string, filter *map[string]any, limit *int, offset *int, query *string, refreshTtl *bool, headers *map[string]string) (schema.SearchItemsResponse, error) {
+	s.searches++
+	return s.MemoryStore.SearchItems(ctx, namespace, filter, limit, offset, query, refreshTtl, headers)
+}
+
+func TestTypedStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	memories := NewTypedStore[testMemory](NewMemoryStore(&MemoryStoreOptions{Now: func() time.Time { return now }}), []string{"users", "u1"})
+
+	assert.NoError(t, memories.Put(ctx, "m1", testMemory{Topic: "billing", Tags: []string{"invoice"}}, nil))
+	value, meta, err := memories.Get(ctx, "m1")
+	assert.NoError(t, err)
+	assert.Equal(t, testMemory{Topic: "billing", Tags: []string{"invoice"}}, value)
+	assert.Equal(t, &ItemMeta{Namespace: []string{"users", "u1"}, Key: "m1", CreatedAt: now, UpdatedAt: now}, meta)
+
+	assert.NoError(t, memories.Delete(ctx, "m1"))
+	_, meta, err = memories.Get(ctx, "m1")
+	assert.ErrorIs(t, err, ErrItemNotFound)
+	assert.Nil(t, meta)
+}
+
+func TestTypedStoreSearch(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	backend := &searchCountingStore{MemoryStore: NewMemoryStore(&MemoryStoreOptions{Now: func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}})}
+	memories := NewTypedStore[testMemory](backend, []string{"users"})
+	memories.pageSize = 2
+
+	for i := 1; i <= 5; i++ {
+		assert.NoError(t, backend.PutItem(ctx, []string{"users", fmt.Sprintf("u%d", i)}, "m", map[string]any{"topic": fmt.Sprintf("topic %d", i)}, nil, nil, nil))
+	}
+	assert.NoError(t, backend.PutItem(ctx, []string{"other"}, "m", map[string]any{"topic": "elsewhere"}, nil, nil, nil))
+
+	// All five items are read across three pages, the last one short.
+	topics := []string{}
+	for item, err := range memories.Search(ctx, nil) {
+		assert.NoError(t, err)
+		topics = append(topics, item.Value.Topic)
+	}
+	assert.Equal(t, []string{"topic 5", "topic 4", "topic 3", "topic 2", "topic 1"}, topics)
+	assert.Equal(t, 3, backend.searches)
+
+	// Breaking early stops before the next page is fetched.
+	backend.searches = 0
+	for item, err := range memories.Search(ctx, nil) {
+		assert.NoError(t, err)
+		if item.Value.Topic == "topic 4" {
+			break
+		}
+	}
+	assert.Equal(t, 1, backend.searches)
+
+	filter := map[string]any{"topic": "topic 2"}
+	topics = []string{}
+	for item, err := range memories.Search(ctx, &filter) {
+		assert.NoError(t, err)
+		topics = append(topics, item.Value.Topic)
+		assert.Equal(t, []string{"users", "u2"}, item.Namespace)
+	}
+	assert.Equal(t, []string{"topic 2"}, topics)
+
+	// Items that do not decode into T are yielded as errors.
+	assert.NoError(t, backend.PutItem(ctx, []string{"users", "u6"}, "m", map[string]any{"topic": 6}, nil, nil, nil))
+	failures := 0
+	for _, err := range memories.Search(ctx, nil) {
+		if err != nil {
+			failures++
+		}
+	}
+	assert.Equal(t, 1, failures)
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// AssistantValidator checks an assistant's config before AssistantsClient
+// creates or updates it. On create, graphID is set and assistantID is set if
+// the caller chose one; on update, assistantID is set and graphID is set if
+// the graph changes.
+type AssistantValidator interface {
+	ValidateAssistant(ctx context.Context, assistantID string, graphID string, config *schema.Config, headers *map[string]string) error
+}
+
+// assistantInvalidator is implemented by validators that cache per assistant
+// and must drop the entry when the assistant's graph changes.
+type assistantInvalidator interface {
+	InvalidateAssistant(assistantID string)
+}
+
+// SchemaValidator validates run input and config.configurable against the
+// JSON Schemas returned by AssistantsClient.GetSchemas. It implements both
+// RunValidator and AssistantValidator, and caches schemas per assistant and
+// per graph. Errors are returned as *ValidationError.
+type SchemaValidator struct {
+	// PartialInput skips "required" checks on run input, since runs usually
+	// send a partial update of the graph state.
+	PartialInput bool
+
+	assistants *AssistantsClient
+	mu         sync.Mutex
+	byID       map[string]schema.GraphSchema
+	byGraph    map[string]schema.GraphSchema
+}
+
+func NewSchemaValidator(assistants *AssistantsClient) *SchemaValidator {
+	return &SchemaValidator{
+		assistants: assistants,
+		byID:       map[string]schema.GraphSchema{},
+		byGraph:    map[string]schema.GraphSchema{},
+	}
+}
+
+func (v *SchemaValidator) ValidateRun(ctx context.Context, run schema.RunCreate, headers *map[string]string) error {
+	if run.Input == nil && (run.Config == nil || run.Config.Configurable == nil) {
+		return nil
+	}
+
+	graphSchema, err := v.schemaForAssistant(ctx, run.AssistantID, headers)
+	if err != nil {
+		return err
+	}
+
+	if run.Input != nil && graphSchema.InputSchema != nil {
+		validator := &schemaValidator{root: schemaMap(*graphSchema.InputSchema), skipRequired: v.PartialInput, errors: []FieldError{}}
+		validator.validate(validator.root, normalizeJSON(run.Input), "", 0)
+		if len(validator.errors) > 0 {
+			return &ValidationError{Subject: "input", Fields: validator.errors}
+		}
+	}
+
+	return validateConfigurable(graphSchema, run.Config)
+}
+
+func (v *SchemaValidator) ValidateAssistant(ctx context.Context, assistantID string, graphID string, config *schema.Config, headers *map[string]string) error {
+	if config == nil || config.Configurable == nil {
+		return nil
+	}
+
+	var (
+		graphSchema schema.GraphSchema
+		err         error
+	)
+	if graphID != "" {
+		graphSchema, err = v.schemaForGraph(ctx, graphID, headers)
+	} else {
+		graphSchema, err = v.schemaForAssistant(ctx, assistantID, headers)
+	}
+	if err != nil {
+		return err
+	}
+
+	return validateConfigurable(graphSchema, config)
+}
+
+// Invalidate drops cached schemas, e.g. after a deployment changed the graphs.
+func (v *SchemaValidator) Invalidate() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.byID = map[string]schema.GraphSchema{}
+	v.byGraph = map[string]schema.GraphSchema{}
+}
+
+// InvalidateAssistant drops the cached schemas of an assistant.
+// AssistantsClient.Update calls it when it changes the assistant's graph.
+func (v *SchemaValidator) InvalidateAssistant(assistantID string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.byID, assistantID)
+}
+
+func (v *SchemaValidator) schemaForAssistant(ctx context.Context, assistantID string, headers *map[string]string) (schema.GraphSchema, error) {
+	v.mu.Lock()
+	graphSchema, ok := v.byID[assistantID]
+	v.mu.Unlock()
+	if ok {
+		return graphSchema, nil
+	}
+
+	graphSchema, err := v.assistants.GetSchemas(ctx, assistantID, headers)
+	if err != nil {
+		return schema.GraphSchema{}, err
+	}
+
+	v.mu.Lock()
+	v.byID[assistantID] = graphSchema
+	v.mu.Unlock()
+
+	return graphSchema, nil
+}
+
+// schemaForGraph looks up the schemas of a graph through any assistant that
+// uses it, since the schemas endpoint is keyed by assistant.
+func (v *SchemaValidator) schemaForGraph(ctx context.Context, graphID string, headers *map[string]string) (schema.GraphSchema, error) {
+	v.mu.Lock()
+	graphSchema, ok := v.byGraph[graphID]
+	v.mu.Unlock()
+	if ok {
+		return graphSchema, nil
+	}
+
+	limit := 1
+	assistants, err := v.assistants.Search(ctx, nil, &graphID, &limit, nil, nil, nil, headers)
+	if err != nil {
+		return schema.GraphSchema{}, err
+	}
+	if len(assistants) == 0 {
+		return schema.GraphSchema{}, fmt.Errorf("no assistant found for graph '%s'", graphID)
+	}
+
+	graphSchema, err = v.schemaForAssistant(ctx, assistants[0].AssistantID, headers)
+	if err != nil {
+		return schema.GraphSchema{}, err
+	}
+
+	v.mu.Lock()
+	v.byGraph[graphID] = graphSchema
+	v.mu.Unlock()
+
+	return graphSchema, nil
+}
+
+// validateConfigurable accepts config schemas describing either the whole
+// config (with a "configurable" property) or the configurable values alone.
+func validateConfigurable(graphSchema schema.GraphSchema, config *schema.Config) error {
+	if config == nil || config.Configurable == nil || graphSchema.ConfigSchema == nil {
+		return nil
+	}
+
+	root := schemaMap(*graphSchema.ConfigSchema)
+	var value any = config.Configurable
+	if properties, ok := root["properties"].(map[string]any); ok {
+		if _, ok := properties["configurable"]; ok {
+			value = map[string]any{"configurable": config.Configurable}
+		}
+	}
+
+	validator := &schemaValidator{root: root, errors: []FieldError{}}
+	validator.validate(root, normalizeJSON(value), "", 0)
+	if len(validator.errors) > 0 {
+		return &ValidationError{Subject: "config.configurable", Fields: validator.errors}
+	}
+
+	return nil
+}
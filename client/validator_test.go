@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/http"
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingValidator struct {
+	assistantIDs []string
+	graphIDs     []string
+}
+
+func (v *recordingValidator) ValidateAssistant(ctx context.Context, assistantID string, graphID string, config *schema.Config, headers *map[string]string) error {
+	v.assistantIDs = append(v.assistantIDs, assistantID)
+	v.graphIDs = append(v.graphIDs, graphID)
+	return nil
+}
+
+func TestAssistantValidatorArguments(t *testing.T) {
+	assistants := NewAssistantsClient(http.NewHttpClient("http://assistants.test", nil, 5*time.Second, roundTripFunc(func(req *nethttp.Request) (int, string) {
+		return 200, `{"assistant_id":"a1","graph_id":"agent"}`
+	})))
+	validator := &recordingValidator{}
+	assistants.AddValidator(validator)
+	ctx := context.Background()
+
+	graphID, assistantID := "agent", "a1"
+	_, err := assistants.Create(ctx, &graphID, nil, nil, &assistantID, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	_, err = assistants.Update(ctx, "a1", nil, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"a1", "a1"}, validator.assistantIDs)
+	assert.Equal(t, []string{"agent", ""}, validator.graphIDs)
+}
+
+func TestSchemaValidatorInvalidatedOnGraphChange(t *testing.T) {
+	fetches := 0
+	assistants := NewAssistantsClient(http.NewHttpClient("http://assistants.test", nil, 5*time.Second, roundTripFunc(func(req *nethttp.Request) (int, string) {
+		if req.URL.Path == "/assistants/a1/schemas" {
+			fetches++
+		}
+		return 200, `{"assistant_id":"a1","graph_id":"agent"}`
+	})))
+	validator := NewSchemaValidator(assistants)
+	assistants.AddValidator(validator)
+	ctx := context.Background()
+
+	run := schema.RunCreate{AssistantID: "a1", Input: schema.Json{"question": "hi"}}
+	assert.NoError(t, validator.ValidateRun(ctx, run, nil))
+	assert.NoError(t, validator.ValidateRun(ctx, run, nil))
+	assert.Equal(t, 1, fetches)
+
+	name := "renamed"
+	_, err := assistants.Update(ctx, "a1", nil, nil, nil, &name, nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, validator.ValidateRun(ctx, run, nil))
+	assert.Equal(t, 1, fetches)
+
+	graphID := "agent-v2"
+	_, err = assistants.Update(ctx, "a1", &graphID, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, validator.ValidateRun(ctx, run, nil))
+	assert.Equal(t, 2, fetches)
+}
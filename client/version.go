@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// AssistantVersionDiff is the difference between two versions of an assistant
+type AssistantVersionDiff struct {
+	AssistantID string                  `json:"assistant_id"` // The ID of the assistant
+	From        schema.AssistantVersion `json:"from"`         // The older version
+	To          schema.AssistantVersion `json:"to"`           // The newer version
+	Changes     []StateChange           `json:"changes"`      // Changes to graph_id, name, description, config and metadata
+}
+
+// IsEmpty reports whether the two versions are identical.
+func (d AssistantVersionDiff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// String renders the diff as human readable text, one change per line.
+func (d AssistantVersionDiff) String() string {
+	stateDiff := StateDiff{
+		FromCheckpoint: fmt.Sprintf("%s@v%d", d.AssistantID, d.From.Version),
+		ToCheckpoint:   fmt.Sprintf("%s@v%d", d.AssistantID, d.To.Version),
+		Changes:        d.Changes,
+	}
+	return stateDiff.String()
+}
+
+// GetVersion returns a single version of an assistant.
+func (c *AssistantsClient) GetVersion(ctx context.Context, assistantID string, version int, headers *map[string]string) (schema.AssistantVersion, error) {
+	const pageSize = 100
+
+	for offset := 0; ; offset += pageSize {
+		limit, pageOffset := pageSize, offset
+		versions, err := c.GetVersions(ctx, assistantID, nil, &limit, &pageOffset, headers)
+		if err != nil {
+			return schema.AssistantVersion{}, err
+		}
+
+		for _, v := range versions {
+			if v.Version == version {
+				return v, nil
+			}
+		}
+
+		if len(versions) < pageSize {
+			return schema.AssistantVersion{}, fmt.Errorf("assistant '%s' has no version %d", assistantID, version)
+		}
+	}
+}
+
+// DiffVersions compares the graph, name, description, config and metadata of
+// two versions of an assistant.
+func (c *AssistantsClient) DiffVersions(ctx context.Context, assistantID string, fromVersion int, toVersion int, headers *map[string]string) (AssistantVersionDiff, error) {
+	from, err := c.GetVersion(ctx, assistantID, fromVersion, headers)
+	if err != nil {
+		return AssistantVersionDiff{}, err
+	}
+
+	to, err := c.GetVersion(ctx, assistantID, toVersion, headers)
+	if err != nil {
+		return AssistantVersionDiff{}, err
+	}
+
+	return DiffAssistantVersions(from, to), nil
+}
+
+// DiffAssistantVersions compares two already fetched versions of an assistant.
+func DiffAssistantVersions(from schema.AssistantVersion, to schema.AssistantVersion) AssistantVersionDiff {
+	changes := []StateChange{}
+	diffValues(versionFields(from), versionFields(to), "", "", &changes)
+
+	return AssistantVersionDiff{
+		AssistantID: to.AssistantID,
+		From:        from,
+		To:          to,
+		Changes:     changes,
+	}
+}
+
+// Rollback makes toVersion the latest version of an assistant after checking
+// that it exists. It returns the version that was latest before the rollback
+// and the version that is latest now.
+func (c *AssistantsClient) Rollback(ctx context.Context, assistantID string, toVersion int, headers *map[string]string) (schema.AssistantVersion, schema.AssistantVersion, error) {
+	target, err := c.GetVersion(ctx, assistantID, toVersion, headers)
+	if err != nil {
+		return schema.AssistantVersion{}, schema.AssistantVersion{}, err
+	}
+
+	current, err := c.Get(ctx, assistantID, headers)
+	if err != nil {
+		return schema.AssistantVersion{}, schema.AssistantVersion{}, err
+	}
+
+	previous, err := c.GetVersion(ctx, assistantID, current.Version, headers)
+	if err != nil {
+		return schema.AssistantVersion{}, schema.AssistantVersion{}, err
+	}
+
+	if current.Version == toVersion {
+		return previous, target, nil
+	}
+
+	_, err = c.SetLatest(ctx, assistantID, &toVersion, headers)
+	if err != nil {
+		return schema.AssistantVersion{}, schema.AssistantVersion{}, fmt.Errorf("rollback of assistant '%s' to version %d: %w", assistantID, toVersion, err)
+	}
+
+	return previous, target, nil
+}
+
+func versionFields(v schema.AssistantVersion) any {
+	fields := map[string]any{
+		"graph_id": v.GraphID,
+		"name":     v.Name,
+		"config":   v.Config,
+		"metadata": v.Metadata,
+	}
+	if v.Description != nil {
+		fields["description"] = *v.Description
+	}
+	return normalizeJSON(fields)
+}
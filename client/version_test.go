@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/http"
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffAssistantVersions(t *testing.T) {
+	description := "Answers billing questions"
+	from := schema.AssistantVersion{
+		AssistantBase: schema.AssistantBase{
+			AssistantID: "a1",
+			GraphID:     "agent",
+			Config:      schema.Config{Configurable: map[string]any{"model": "gpt-4o"}},
+			Metadata:    schema.Json{"team": "support"},
+			Version:     1,
+		},
+		Name: "Support",
+	}
+	to := from
+	to.Version = 2
+	to.Name = "Billing"
+	to.Description = &description
+	to.Config = schema.Config{Configurable: map[string]any{"model": "gpt-4.1"}}
+
+	diff := DiffAssistantVersions(from, to)
+
+	assert.Equal(t, "a1", diff.AssistantID)
+	assert.Equal(t, []StateChange{
+		{Kind: StateChangeChanged, Path: "/config/configurable/model", From: "gpt-4o", To: "gpt-4.1"},
+		{Kind: StateChangeAdded, Path: "/description", To: description},
+		{Kind: StateChangeChanged, Path: "/name", From: "Support", To: "Billing"},
+	}, diff.Changes)
+	assert.Contains(t, diff.String(), "a1@v1")
+	assert.True(t, DiffAssistantVersions(from, from).IsEmpty())
+}
+
+func TestRollback(t *testing.T) {
+	latest := 2
+	var setLatest []int
+	assistants := NewAssistantsClient(http.NewHttpClient("http://assistants.test", nil, 5*time.Second, roundTripFunc(func(req *nethttp.Request) (int, string) {
+		switch req.Method + " " + req.URL.Path {
+		case "POST /assistants/a1/versions":
+			return 200, `[{"assistant_id":"a1","graph_id":"agent","version":2,"name":"new"},{"assistant_id":"a1","graph_id":"agent","version":1,"name":"old"}]`
+		case "GET /assistants/a1":
+			data, _ := json.Marshal(map[string]any{"assistant_id": "a1", "graph_id": "agent", "version": latest})
+			return 200, string(data)
+		case "POST /assistants/a1/versions/latest":
+			var body struct {
+				Version int `json:"version"`
+			}
+			json.NewDecoder(req.Body).Decode(&body)
+			setLatest = append(setLatest, body.Version)
+			latest = body.Version
+			return 200, `{"assistant_id":"a1","graph_id":"agent"}`
+		}
+		return 404, `{"detail":"not found"}`
+	})))
+	ctx := context.Background()
+
+	_, _, err := assistants.Rollback(ctx, "a1", 5, nil)
+	assert.EqualError(t, err, "assistant 'a1' has no version 5")
+	assert.Empty(t, setLatest)
+
+	previous, current, err := assistants.Rollback(ctx, "a1", 1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", previous.Name)
+	assert.Equal(t, "old", current.Name)
+	assert.Equal(t, []int{1}, setLatest)
+}
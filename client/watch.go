@@ -0,0 +1,229 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	nethttp "net/http"
+	"sync"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/http"
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// ThreadStatusEvent reports a change in a watched thread's status
+type ThreadStatusEvent struct {
+	ThreadID string              // The ID of the thread
+	Previous schema.ThreadStatus // The previously observed status; empty on the first observation
+	Current  schema.ThreadStatus // The newly observed status
+	Thread   schema.Thread       // The latest thread, including its interrupts and values
+	Err      error               // Set when the thread could not be fetched; the other fields are then empty. Repeated failures are reported once until the error changes
+}
+
+// ThreadWatcher polls a changing set of threads and emits status transitions
+type ThreadWatcher struct {
+	client   *ThreadsClient
+	interval time.Duration
+	headers  *map[string]string
+	events   chan ThreadStatusEvent
+
+	mu       sync.Mutex
+	statuses map[string]schema.ThreadStatus
+	lastErrs map[string]string // Last error reported per thread, to report repeated failures once
+	noSearch bool              // Set once the server is found not to support searching by IDs
+}
+
+// Watch polls the given threads every interval until ctx is cancelled and
+// emits an event on the first observation of each thread and whenever its
+// status changes. Threads are fetched with a single search request per poll,
+// falling back to one Get per thread if the server does not support it.
+func (c *ThreadsClient) Watch(ctx context.Context, threadIDs []string, interval time.Duration, headers *map[string]string) *ThreadWatcher {
+	w := newThreadWatcher(c, interval, headers)
+	w.Add(threadIDs...)
+
+	go w.run(ctx)
+
+	return w
+}
+
+func newThreadWatcher(c *ThreadsClient, interval time.Duration, headers *map[string]string) *ThreadWatcher {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	return &ThreadWatcher{
+		client:   c,
+		interval: interval,
+		headers:  headers,
+		events:   make(chan ThreadStatusEvent),
+		statuses: map[string]schema.ThreadStatus{},
+		lastErrs: map[string]string{},
+	}
+}
+
+// Events returns the channel of status events. It is closed when the
+// watcher's context is cancelled.
+func (w *ThreadWatcher) Events() <-chan ThreadStatusEvent {
+	return w.events
+}
+
+// Add starts watching the given threads from the next poll.
+func (w *ThreadWatcher) Add(threadIDs ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, id := range threadIDs {
+		if _, ok := w.statuses[id]; !ok {
+			w.statuses[id] = ""
+		}
+	}
+}
+
+// Remove stops watching the given threads.
+func (w *ThreadWatcher) Remove(threadIDs ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, id := range threadIDs {
+		delete(w.statuses, id)
+		delete(w.lastErrs, id)
+	}
+}
+
+// ThreadIDs returns the threads currently being watched.
+func (w *ThreadWatcher) ThreadIDs() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	ids := make([]string, 0, len(w.statuses))
+	for id := range w.statuses {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (w *ThreadWatcher) run(ctx context.Context) {
+	defer close(w.events)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		for _, event := range w.poll(ctx) {
+			select {
+			case <-ctx.Done():
+				return
+			case w.events <- event:
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *ThreadWatcher) poll(ctx context.Context) []ThreadStatusEvent {
+	ids := w.ThreadIDs()
+	if len(ids) == 0 {
+		return nil
+	}
+
+	threads, errs := w.fetch(ctx, ids)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	events := []ThreadStatusEvent{}
+	for _, id := range ids {
+		previous, watched := w.statuses[id]
+		if !watched {
+			// Removed while the poll was in flight.
+			continue
+		}
+		if err, ok := errs[id]; ok {
+			if w.lastErrs[id] != err.Error() {
+				w.lastErrs[id] = err.Error()
+				events = append(events, ThreadStatusEvent{ThreadID: id, Err: err})
+			}
+			continue
+		}
+		delete(w.lastErrs, id)
+		thread, ok := threads[id]
+		if !ok || (previous != "" && thread.Status == previous) {
+			continue
+		}
+		w.statuses[id] = thread.Status
+		events = append(events, ThreadStatusEvent{
+			ThreadID: id,
+			Previous: previous,
+			Current:  thread.Status,
+			Thread:   thread,
+		})
+	}
+
+	return events
+}
+
+func (w *ThreadWatcher) fetch(ctx context.Context, ids []string) (map[string]schema.Thread, map[string]error) {
+	threads := make(map[string]schema.Thread, len(ids))
+	errs := map[string]error{}
+
+	w.mu.Lock()
+	noSearch := w.noSearch
+	w.mu.Unlock()
+
+	if !noSearch {
+		found, err := w.client.searchByIDs(ctx, ids, w.headers)
+		var httpErr *http.HTTPError
+		switch {
+		case err == nil:
+			for _, thread := range found {
+				threads[thread.ThreadID] = thread
+			}
+		case errors.As(err, &httpErr) && (httpErr.StatusCode == nethttp.StatusNotFound ||
+			httpErr.StatusCode == nethttp.StatusMethodNotAllowed || httpErr.StatusCode == nethttp.StatusUnprocessableEntity):
+			// The server does not support searching by IDs; other errors
+			// are transient and the search is retried on the next poll.
+			w.mu.Lock()
+			w.noSearch = true
+			w.mu.Unlock()
+		}
+	}
+
+	// Threads missing from the search results are fetched individually so
+	// that deleted threads surface as errors.
+	for _, id := range ids {
+		if _, ok := threads[id]; ok {
+			continue
+		}
+		thread, err := w.client.Get(ctx, id, w.headers)
+		if err != nil {
+			errs[id] = err
+			continue
+		}
+		threads[id] = thread
+	}
+
+	return threads, errs
+}
+
+func (c *ThreadsClient) searchByIDs(ctx context.Context, threadIDs []string, headers *map[string]string) ([]schema.Thread, error) {
+	payload := map[string]any{
+		"ids":   threadIDs,
+		"limit": len(threadIDs),
+	}
+
+	resp, err := c.http.Post(ctx, "/threads/search", payload, headers)
+	if err != nil {
+		return []schema.Thread{}, err
+	}
+
+	var threads []schema.Thread
+	err = json.Unmarshal(resp.Body(), &threads)
+	if err != nil {
+		return []schema.Thread{}, err
+	}
+
+	return threads, nil
+}
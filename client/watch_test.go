@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	nethttp "net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/http"
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeThreadServer serves thread lookups and searches by ID from a map of
+// thread statuses.
+type fakeThreadServer struct {
+	mu           sync.Mutex
+	statuses     map[string]schema.ThreadStatus
+	searchStatus int    // Status returned by searches instead of results, if set
+	onSearch     func() // Called while a search is in flight
+	searches     int
+	gets         int
+}
+
+func (f *fakeThreadServer) serve(req *nethttp.Request) (int, string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	thread := func(id string) string {
+		return fmt.Sprintf(`{"thread_id":%q,"status":%q}`, id, f.statuses[id])
+	}
+
+	if req.Method == "POST" && req.URL.Path == "/threads/search" {
+		f.searches++
+		if f.onSearch != nil {
+			f.mu.Unlock()
+			f.onSearch()
+			f.mu.Lock()
+		}
+		if f.searchStatus != 0 {
+			return f.searchStatus, `{"detail":"search failed"}`
+		}
+		var body struct {
+			IDs []string `json:"ids"`
+		}
+		json.NewDecoder(req.Body).Decode(&body)
+		found := []string{}
+		for _, id := range body.IDs {
+			if _, ok := f.statuses[id]; ok {
+				found = append(found, thread(id))
+			}
+		}
+		return 200, "[" + strings.Join(found, ",") + "]"
+	}
+
+	f.gets++
+	id := strings.TrimPrefix(req.URL.Path, "/threads/")
+	if _, ok := f.statuses[id]; !ok {
+		return 404, `{"detail":"Thread not found"}`
+	}
+	return 200, thread(id)
+}
+
+func newTestWatcher(server *fakeThreadServer) *ThreadWatcher {
+	threads := NewThreadsClient(http.NewHttpClient("http://threads.test", nil, 5*time.Second, roundTripFunc(server.serve)))
+	return newThreadWatcher(threads, time.Millisecond, nil)
+}
+
+func TestThreadWatcherTransitions(t *testing.T) {
+	server := &fakeThreadServer{statuses: map[string]schema.ThreadStatus{"t1": "idle"}}
+	threads := NewThreadsClient(http.NewHttpClient("http://threads.test", nil, 5*time.Second, roundTripFunc(server.serve)))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := threads.Watch(ctx, []string{"t1"}, time.Millisecond, nil)
+
+	next := func() ThreadStatusEvent {
+		select {
+		case event := <-w.Events():
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event")
+			return ThreadStatusEvent{}
+		}
+	}
+	setStatus := func(status schema.ThreadStatus) {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		server.statuses["t1"] = status
+	}
+
+	event := next()
+	assert.Equal(t, schema.ThreadStatus(""), event.Previous)
+	assert.Equal(t, schema.ThreadStatus("idle"), event.Current)
+
+	setStatus("busy")
+	event = next()
+	assert.Equal(t, schema.ThreadStatus("idle"), event.Previous)
+	assert.Equal(t, schema.ThreadStatus("busy"), event.Current)
+	assert.Equal(t, "t1", event.Thread.ThreadID)
+
+	setStatus("interrupted")
+	event = next()
+	assert.Equal(t, schema.ThreadStatus("busy"), event.Previous)
+	assert.Equal(t, schema.ThreadStatus("interrupted"), event.Current)
+
+	cancel()
+	for range w.Events() {
+	}
+}
+
+func TestThreadWatcherReportsErrorsOnce(t *testing.T) {
+	server := &fakeThreadServer{statuses: map[string]schema.ThreadStatus{"t1": "idle"}}
+	w := newTestWatcher(server)
+	w.Add("t1", "gone")
+	ctx := context.Background()
+
+	events := w.poll(ctx)
+	assert.Len(t, events, 2)
+	for _, event := range events {
+		if event.ThreadID == "gone" {
+			assert.ErrorContains(t, event.Err, "404")
+		}
+	}
+
+	for range 3 {
+		assert.Empty(t, w.poll(ctx))
+	}
+
+	server.mu.Lock()
+	server.statuses["gone"] = "idle"
+	server.mu.Unlock()
+	events = w.poll(ctx)
+	assert.Len(t, events, 1)
+	assert.Equal(t, schema.ThreadStatus("idle"), events[0].Current)
+	assert.NoError(t, events[0].Err)
+}
+
+func TestThreadWatcherAddRemoveDuringPoll(t *testing.T) {
+	server := &fakeThreadServer{statuses: map[string]schema.ThreadStatus{"t1": "idle", "t2": "busy", "t3": "idle"}}
+	w := newTestWatcher(server)
+	w.Add("t1", "t2")
+
+	server.onSearch = func() {
+		server.onSearch = nil
+		w.Remove("t1")
+		w.Add("t3")
+	}
+
+	events := w.poll(context.Background())
+	assert.Len(t, events, 1)
+	assert.Equal(t, "t2", events[0].ThreadID)
+
+	events = w.poll(context.Background())
+	assert.Len(t, events, 1)
+	assert.Equal(t, "t3", events[0].ThreadID)
+	assert.ElementsMatch(t, []string{"t2", "t3"}, w.ThreadIDs())
+}
+
+func TestThreadWatcherSearchFallback(t *testing.T) {
+	server := &fakeThreadServer{statuses: map[string]schema.ThreadStatus{"t1": "idle", "t2": "busy"}, searchStatus: 503}
+	w := newTestWatcher(server)
+	w.Add("t1", "t2")
+	ctx := context.Background()
+
+	// A transient failure falls back to Get for one poll only.
+	assert.Len(t, w.poll(ctx), 2)
+	assert.Equal(t, 1, server.searches)
+	assert.Equal(t, 2, server.gets)
+
+	server.searchStatus = 0
+	server.statuses["t1"] = "busy"
+	assert.Len(t, w.poll(ctx), 1)
+	assert.Equal(t, 2, server.searches)
+	assert.Equal(t, 2, server.gets)
+
+	// An unsupported search is not tried again.
+	server.searchStatus = 404
+	server.statuses["t2"] = "idle"
+	assert.Len(t, w.poll(ctx), 1)
+	server.searchStatus = 0
+	assert.Empty(t, w.poll(ctx))
+	assert.Equal(t, 3, server.searches)
+	assert.Equal(t, 6, server.gets)
+}
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+// generator turns graph JSON Schemas into Go type declarations.
+type generator struct {
+	packageName string
+	source      string
+
+	decls []string
+	// named maps a generated type name to the JSON of the schema it was
+	// generated from, so identical $defs shared between schemas are emitted once.
+	named map[string]string
+}
+
+func newGenerator(packageName string, source string) *generator {
+	return &generator{packageName: packageName, source: source, named: map[string]string{}}
+}
+
+// addGraphSchema generates the Input, Output, State and Config types of a
+// graph, prefixing each type name with prefix.
+func (g *generator) addGraphSchema(prefix string, graphSchema schema.GraphSchema) error {
+	schemas := []struct {
+		suffix string
+		schema *schema.Json
+	}{
+		{"Input", graphSchema.InputSchema},
+		{"Output", graphSchema.OutputSchema},
+		{"State", graphSchema.StateSchema},
+		{"Config", graphSchema.ConfigSchema},
+	}
+
+	for _, s := range schemas {
+		if s.schema == nil {
+			continue
+		}
+		root, err := toMap(*s.schema)
+		if err != nil {
+			return fmt.Errorf("%s%s: %w", prefix, s.suffix, err)
+		}
+		g.namedType(prefix+s.suffix, root, root, prefix)
+	}
+
+	return nil
+}
+
+// Source returns the formatted Go source of every generated type.
+func (g *generator) Source() ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by langgraph-gen from %s. DO NOT EDIT.\n\n", g.source)
+	fmt.Fprintf(&b, "package %s\n\n", g.packageName)
+	for _, decl := range g.decls {
+		b.WriteString(decl)
+		b.WriteString("\n")
+	}
+
+	return format.Source(b.Bytes())
+}
+
+// namedType declares a type called name for s and returns the name actually used.
+func (g *generator) namedType(name string, s map[string]any, root map[string]any, prefix string) string {
+	key := schemaKey(s)
+	if existing, ok := g.named[name]; ok {
+		if existing == key {
+			return name
+		}
+		// Fall back to the prefixed name, then to numbered variants of it.
+		base := prefix + name
+		name = base
+		for i := 2; ; i++ {
+			existing, ok := g.named[name]
+			if !ok {
+				break
+			}
+			if existing == key {
+				return name
+			}
+			name = fmt.Sprintf("%s%d", base, i)
+		}
+	}
+	g.named[name] = key
+
+	var b strings.Builder
+	title, _ := s["title"].(string)
+	if title == "" {
+		title = name
+	}
+	writeComment(&b, "", fmt.Sprintf("%s is generated from the %s schema.", name, title))
+	if description, ok := s["description"].(string); ok && description != "" {
+		b.WriteString("//\n")
+		writeComment(&b, "", description)
+	}
+
+	properties, _ := s["properties"].(map[string]any)
+	if properties == nil {
+		fmt.Fprintf(&b, "type %s %s\n", name, g.goType(s, root, prefix, name))
+		g.decls = append(g.decls, b.String())
+		return name
+	}
+
+	required := map[string]bool{}
+	if list, ok := s["required"].([]any); ok {
+		for _, r := range list {
+			if s, ok := r.(string); ok {
+				required[s] = true
+			}
+		}
+	}
+
+	fields := make([]string, 0, len(properties))
+	for field := range properties {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	// Reserve the slot so nested types are declared after this one.
+	index := len(g.decls)
+	g.decls = append(g.decls, "")
+
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	for _, field := range fields {
+		propSchema, _ := properties[field].(map[string]any)
+		fieldName := exportedName(field)
+		fieldType := g.goType(propSchema, root, prefix, name+fieldName)
+		tag := field
+		if !required[field] {
+			tag += ",omitempty"
+		}
+		if description, ok := propSchema["description"].(string); ok && description != "" {
+			writeComment(&b, "\t", description)
+		}
+		fmt.Fprintf(&b, "\t%s %s `json:%q`\n", fieldName, fieldType, tag)
+	}
+	b.WriteString("}\n")
+
+	g.decls[index] = b.String()
+	return name
+}
+
+// goType returns the Go type for s, declaring named types for nested objects.
+func (g *generator) goType(s map[string]any, root map[string]any, prefix string, nameHint string) string {
+	if s == nil {
+		return "any"
+	}
+
+	if ref, ok := s["$ref"].(string); ok {
+		resolved := resolveRef(root, ref)
+		if resolved == nil {
+			return "any"
+		}
+		name := g.namedType(exportedName(ref[strings.LastIndex(ref, "/")+1:]), resolved, root, prefix)
+		if _, ok := resolved["properties"]; ok {
+			return "*" + name
+		}
+		return name
+	}
+
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		if options, ok := s[keyword].([]any); ok {
+			nonNull := []map[string]any{}
+			for _, option := range options {
+				if m, ok := option.(map[string]any); ok && m["type"] != "null" {
+					nonNull = append(nonNull, m)
+				}
+			}
+			if len(nonNull) == 1 {
+				return nullable(g.goType(nonNull[0], root, prefix, nameHint), len(nonNull) < len(options))
+			}
+			return "any"
+		}
+	}
+
+	if allOf, ok := s["allOf"].([]any); ok && len(allOf) == 1 {
+		if m, ok := allOf[0].(map[string]any); ok {
+			return g.goType(m, root, prefix, nameHint)
+		}
+	}
+
+	typeName, isNullable := schemaType(s["type"])
+	var goType string
+	switch typeName {
+	case "string":
+		goType = "string"
+	case "integer":
+		goType = "int64"
+	case "number":
+		goType = "float64"
+	case "boolean":
+		goType = "bool"
+	case "array":
+		items, _ := s["items"].(map[string]any)
+		// Slices break recursive definitions, so items are stored by value.
+		return "[]" + strings.TrimPrefix(g.goType(items, root, prefix, nameHint+"Item"), "*")
+	case "object":
+		if _, ok := s["properties"].(map[string]any); ok {
+			return "*" + g.namedType(nameHint, s, root, prefix)
+		}
+		if additional, ok := s["additionalProperties"].(map[string]any); ok {
+			return "map[string]" + g.goType(additional, root, prefix, nameHint+"Value")
+		}
+		return "map[string]any"
+	default:
+		if _, ok := s["properties"].(map[string]any); ok {
+			return "*" + g.namedType(nameHint, s, root, prefix)
+		}
+		return "any"
+	}
+
+	return nullable(goType, isNullable)
+}
+
+func nullable(goType string, isNullable bool) string {
+	if !isNullable || strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "[]") ||
+		strings.HasPrefix(goType, "map[") || goType == "any" {
+		return goType
+	}
+	return "*" + goType
+}
+
+func schemaType(t any) (string, bool) {
+	switch v := t.(type) {
+	case string:
+		return v, false
+	case []any:
+		name, isNullable := "", false
+		for _, item := range v {
+			s, _ := item.(string)
+			if s == "null" {
+				isNullable = true
+			} else if name == "" {
+				name = s
+			}
+		}
+		return name, isNullable
+	}
+	return "", false
+}
+
+func resolveRef(root map[string]any, ref string) map[string]any {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil
+	}
+	var current any = root
+	for _, token := range strings.Split(ref[2:], "/") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = m[token]
+	}
+	resolved, _ := current.(map[string]any)
+	return resolved
+}
+
+// exportedName converts snake_case, kebab-case or dotted names to an exported
+// Go identifier.
+func exportedName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	out := b.String()
+	if out == "" {
+		return "Field"
+	}
+	if unicode.IsDigit(rune(out[0])) {
+		out = "X" + out
+	}
+	for _, initialism := range []string{"Id", "Url", "Api", "Json", "Llm"} {
+		if strings.HasSuffix(out, initialism) {
+			out = strings.TrimSuffix(out, initialism) + strings.ToUpper(initialism)
+		}
+	}
+	return out
+}
+
+func writeComment(b *strings.Builder, indent string, text string) {
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		fmt.Fprintf(b, "%s// %s\n", indent, strings.TrimSpace(line))
+	}
+}
+
+func schemaKey(s map[string]any) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+func toMap(value any) (map[string]any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
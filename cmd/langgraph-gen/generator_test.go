@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratorSharesDefinitions(t *testing.T) {
+	var graphSchema schema.GraphSchema
+	err := json.Unmarshal([]byte(`{
+		"graph_id": "agent",
+		"input_schema": {
+			"type": "object",
+			"properties": {"messages": {"type": "array", "items": {"$ref": "#/$defs/Message"}}},
+			"required": ["messages"],
+			"$defs": {"Message": {"type": "object", "properties": {"id": {"type": "string"}}}}
+		},
+		"state_schema": {
+			"type": "object",
+			"properties": {
+				"messages": {"type": "array", "items": {"$ref": "#/$defs/Message"}},
+				"step": {"type": ["integer", "null"]}
+			},
+			"$defs": {"Message": {"type": "object", "properties": {"id": {"type": "string"}}}}
+		}
+	}`), &graphSchema)
+	assert.NoError(t, err)
+
+	g := newGenerator("agent", "test")
+	assert.NoError(t, g.addGraphSchema("Agent", graphSchema))
+	src, err := g.Source()
+	assert.NoError(t, err)
+
+	out := string(src)
+	assert.Contains(t, out, "type AgentInput struct {\n\tMessages []Message `json:\"messages\"`\n}")
+	assert.Contains(t, out, "\tStep     *int64    `json:\"step,omitempty\"`\n")
+	assert.Contains(t, out, "\tID string `json:\"id,omitempty\"`\n")
+	assert.Equal(t, 1, strings.Count(out, "type Message struct"))
+}
+
+func TestGeneratorRenamesConflictingDefinitions(t *testing.T) {
+	var graphSchema schema.GraphSchema
+	err := json.Unmarshal([]byte(`{
+		"graph_id": "agent",
+		"input_schema": {
+			"type": "object",
+			"properties": {"message": {"$ref": "#/$defs/Message"}},
+			"$defs": {"Message": {"type": "object", "properties": {"id": {"type": "string"}}}}
+		},
+		"output_schema": {
+			"type": "object",
+			"properties": {"message": {"$ref": "#/$defs/Message"}},
+			"$defs": {"Message": {"type": "object", "properties": {"text": {"type": "string"}}}}
+		},
+		"state_schema": {
+			"type": "object",
+			"properties": {
+				"message": {"$ref": "#/$defs/Message"},
+				"last": {"$ref": "#/$defs/Message"}
+			},
+			"$defs": {"Message": {"type": "object", "properties": {"count": {"type": "integer"}}}}
+		}
+	}`), &graphSchema)
+	assert.NoError(t, err)
+
+	g := newGenerator("agent", "test")
+	assert.NoError(t, g.addGraphSchema("Agent", graphSchema))
+	src, err := g.Source()
+	assert.NoError(t, err)
+
+	out := string(src)
+	assert.Equal(t, 1, strings.Count(out, "type Message struct {\n\tID string"))
+	assert.Equal(t, 1, strings.Count(out, "type AgentMessage struct {\n\tText string"))
+	assert.Equal(t, 1, strings.Count(out, "type AgentMessage2 struct {\n\tCount int64"))
+	assert.Contains(t, out, "type AgentInput struct {\n\tMessage *Message ")
+	assert.Contains(t, out, "type AgentOutput struct {\n\tMessage *AgentMessage ")
+	assert.Contains(t, out, "\tLast    *AgentMessage2 `json:\"last,omitempty\"`\n\tMessage *AgentMessage2 ")
+}
@@ -0,0 +1,124 @@
+// Command langgraph-gen generates Go types from the JSON Schemas of a
+// LangGraph graph, so that Go code stays in sync with the Python graph
+// definitions.
+//
+// Schemas are fetched from a running server with AssistantsClient.GetSchemas
+// (and GetSubgraphs with -subgraphs), or read from a JSON file saved from the
+// schemas endpoint. For each schema it emits <Prefix>Input, <Prefix>Output,
+// <Prefix>State and <Prefix>Config types.
+//
+// Typical go:generate usage:
+//
+//	//go:generate go run github.com/KhanhD1nh/langgraph-sdk-go/cmd/langgraph-gen -assistant agent -subgraphs -out agent_types.go
+//
+// When run through go:generate, the package name defaults to $GOPACKAGE.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	langgraph_sdk "github.com/KhanhD1nh/langgraph-sdk-go"
+	"github.com/KhanhD1nh/langgraph-sdk-go/schema"
+)
+
+func main() {
+	var (
+		url         = flag.String("url", "", "LangGraph API URL (default http://localhost:2024)")
+		apiKey      = flag.String("api-key", "", "API key (default from LANGGRAPH_API_KEY, LANGSMITH_API_KEY or LANGCHAIN_API_KEY)")
+		assistantID = flag.String("assistant", "", "assistant or graph ID to fetch schemas for")
+		file        = flag.String("file", "", "read schemas from a saved JSON file instead of the server")
+		subgraphs   = flag.Bool("subgraphs", false, "also generate types for subgraphs, recursively")
+		packageName = flag.String("package", os.Getenv("GOPACKAGE"), "package name of the generated file")
+		prefix      = flag.String("prefix", "", "prefix for generated type names (default derived from the graph ID)")
+		out         = flag.String("out", "", "output file (default stdout)")
+	)
+	flag.Parse()
+
+	if err := run(*url, *apiKey, *assistantID, *file, *subgraphs, *packageName, *prefix, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "langgraph-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(url string, apiKey string, assistantID string, file string, withSubgraphs bool, packageName string, prefix string, out string) error {
+	if packageName == "" {
+		return fmt.Errorf("-package is required outside of go:generate")
+	}
+	if (assistantID == "") == (file == "") {
+		return fmt.Errorf("exactly one of -assistant and -file is required")
+	}
+
+	var (
+		graphSchema schema.GraphSchema
+		subgraphs   schema.Subgraphs
+		source      string
+	)
+
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &graphSchema); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		source = file
+	} else {
+		ctx := context.Background()
+		client := langgraph_sdk.GetClient(url, apiKey, nil)
+
+		var err error
+		graphSchema, err = client.Assistants.GetSchemas(ctx, assistantID, nil)
+		if err != nil {
+			return fmt.Errorf("fetching schemas of %s: %w", assistantID, err)
+		}
+
+		if withSubgraphs {
+			recurse := true
+			subgraphs, err = client.Assistants.GetSubgraphs(ctx, assistantID, nil, &recurse, nil)
+			if err != nil {
+				return fmt.Errorf("fetching subgraphs of %s: %w", assistantID, err)
+			}
+		}
+		source = "assistant " + assistantID
+	}
+
+	if prefix == "" && graphSchema.GraphID != "" {
+		prefix = exportedName(graphSchema.GraphID)
+	} else if prefix == "" {
+		prefix = exportedName(assistantID)
+	}
+
+	g := newGenerator(packageName, source)
+	if err := g.addGraphSchema(prefix, graphSchema); err != nil {
+		return err
+	}
+
+	namespaces := make([]string, 0, len(subgraphs))
+	for namespace := range subgraphs {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		if err := g.addGraphSchema(prefix+exportedName(strings.ReplaceAll(namespace, "|", "_")), subgraphs[namespace]); err != nil {
+			return err
+		}
+	}
+
+	src, err := g.Source()
+	if err != nil {
+		return fmt.Errorf("formatting generated code: %w", err)
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
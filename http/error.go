@@ -6,12 +6,22 @@ import (
 	"github.com/go-resty/resty/v2"
 )
 
+// HTTPError is returned for responses with a 4xx or 5xx status code
+type HTTPError struct {
+	StatusCode int    // The HTTP status code of the response
+	Body       string // The response body
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP error: %d - %s", e.StatusCode, e.Body)
+}
+
 func handleError(resp *resty.Response, err error) error {
 	if err != nil {
 		return err
 	}
 	if resp.IsError() {
-		return fmt.Errorf("HTTP error: %d - %s", resp.StatusCode(), string(resp.Body()))
+		return &HTTPError{StatusCode: resp.StatusCode(), Body: string(resp.Body())}
 	}
 	return nil
 }
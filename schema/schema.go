@@ -12,6 +12,7 @@ type RunStatus string
 
 const (
 	RunStatusPending     RunStatus = "pending"     // The run is waiting to start
+	RunStatusRunning     RunStatus = "running"     // The run is executing
 	RunStatusError       RunStatus = "error"       // The run encountered an error and stopped
 	RunStatusSuccess     RunStatus = "success"     // The run completed successfully
 	RunStatusTimeout     RunStatus = "timeout"     // The run exceeded its time limit
@@ -135,8 +136,10 @@ type Node struct {
 
 // Edge represents an edge in a graph
 type Edge struct {
-	Source string `json:"source"` // The source node ID
-	Target string `json:"target"` // The target node ID
+	Source      string `json:"source"`                // The source node ID
+	Target      string `json:"target"`                // The target node ID
+	Data        any    `json:"data,omitempty"`        // The data associated with the edge, usually its label
+	Conditional bool   `json:"conditional,omitempty"` // Whether the edge is taken conditionally
 }
 
 // Subgraphs is a map of graph names to their schemas
@@ -155,13 +158,16 @@ type AssistantBase struct {
 // AssistantVersion represents a specific version of an assistant
 type AssistantVersion struct {
 	AssistantBase
+	Name        string  `json:"name,omitempty"`        // The name of the assistant at this version
+	Description *string `json:"description,omitempty"` // The description of the assistant at this version
 }
 
 // Assistant represents an assistant with additional properties
 type Assistant struct {
 	AssistantBase
-	UpdatedAt time.Time `json:"updated_at"` // The last time the assistant was updated
-	Name      string    `json:"name"`       // The name of the assistant
+	UpdatedAt   time.Time `json:"updated_at"`            // The last time the assistant was updated
+	Name        string    `json:"name"`                  // The name of the assistant
+	Description *string   `json:"description,omitempty"` // The description of the assistant
 }
 
 // InterruptWhen defines when an interrupt occurred
@@ -227,15 +233,30 @@ type Run struct {
 	MultitaskStrategy MultitaskStrategy `json:"multitask_strategy"` // Strategy to handle concurrent runs on the same thread
 }
 
+// OnRunCompleted specifies what happens to the thread created for a stateless cron run
+type OnRunCompleted string
+
+const (
+	OnRunCompletedDelete OnRunCompleted = "delete" // Delete the thread once the run completes
+	OnRunCompletedKeep   OnRunCompleted = "keep"   // Keep the thread once the run completes
+)
+
 // Cron represents a scheduled task
 type Cron struct {
-	CronID    string     `json:"cron_id"`             // The ID of the cron
-	ThreadID  *string    `json:"thread_id,omitempty"` // The ID of the thread
-	EndTime   *time.Time `json:"end_time,omitempty"`  // The end date to stop running the cron
-	Schedule  string     `json:"schedule"`            // The schedule to run, cron format
-	CreatedAt time.Time  `json:"created_at"`          // The time the cron was created
-	UpdatedAt time.Time  `json:"updated_at"`          // The last time the cron was updated
-	Payload   Json       `json:"payload"`             // The run payload to use for creating new run
+	CronID         string          `json:"cron_id"`                    // The ID of the cron
+	AssistantID    *string         `json:"assistant_id,omitempty"`     // The ID of the assistant
+	ThreadID       *string         `json:"thread_id,omitempty"`        // The ID of the thread
+	UserID         *string         `json:"user_id,omitempty"`          // The ID of the user that owns the cron
+	EndTime        *time.Time      `json:"end_time,omitempty"`         // The end date to stop running the cron
+	Schedule       string          `json:"schedule"`                   // The schedule to run, cron format
+	CreatedAt      time.Time       `json:"created_at"`                 // The time the cron was created
+	UpdatedAt      time.Time       `json:"updated_at"`                 // The last time the cron was updated
+	Payload        Json            `json:"payload"`                    // The run payload to use for creating new run
+	NextRunDate    *time.Time      `json:"next_run_date,omitempty"`    // The next time the cron will run
+	Metadata       Json            `json:"metadata,omitempty"`         // The cron metadata
+	Enabled        *bool           `json:"enabled,omitempty"`          // Whether the cron is active
+	OnRunCompleted *OnRunCompleted `json:"on_run_completed,omitempty"` // What to do with the thread of a stateless cron run once it completes
+	NextRuns       []time.Time     `json:"-"`                          // Upcoming fire times, computed client-side by client.AnnotateNextRuns
 }
 
 // RunCreate defines the parameters for initiating a background run
@@ -254,11 +275,12 @@ type RunCreate struct {
 
 // Item represents a single document or data entry in the graph's Store
 type Item struct {
-	Namespace []string               `json:"namespace"`  // The namespace of the item
-	Key       string                 `json:"key"`        // The unique identifier of the item within its namespace
-	Value     map[string]interface{} `json:"value"`      // The value stored in the item
-	CreatedAt time.Time              `json:"created_at"` // The timestamp when the item was created
-	UpdatedAt time.Time              `json:"updated_at"` // The timestamp when the item was last updated
+	Namespace []string               `json:"namespace"`            // The namespace of the item
+	Key       string                 `json:"key"`                  // The unique identifier of the item within its namespace
+	Value     map[string]interface{} `json:"value"`                // The value stored in the item
+	CreatedAt time.Time              `json:"created_at"`           // The timestamp when the item was created
+	UpdatedAt time.Time              `json:"updated_at"`           // The timestamp when the item was last updated
+	ExpiresAt *time.Time             `json:"expires_at,omitempty"` // When the item expires if not refreshed; only set for items with a TTL on servers that report it
 }
 
 // ListNamespaceResponse is the response structure for listing namespaces
@@ -284,6 +306,18 @@ type StreamPart struct {
 	MetaData string `json:"metadata"` // Additional metadata associated with the event
 }
 
+// StateUpdate is a single state update applied as if it were written by a node
+type StateUpdate struct {
+	Values  any      `json:"values"`            // The values to write to the state
+	AsNode  string   `json:"as_node"`           // The node the update is attributed to
+	Command *Command `json:"command,omitempty"` // Optional command to apply with the update
+}
+
+// Superstep is a group of state updates applied together in one step
+type Superstep struct {
+	Updates []StateUpdate `json:"updates"` // The updates applied in this superstep
+}
+
 // Send is a structure for directing input to a specific node
 type Send struct {
 	Node  string `json:"node"`            // The node to send input to
@@ -325,3 +359,35 @@ const (
 	ThreadSortByCreatedAt ThreadSortBy = "created_at" // The time the thread was created
 	ThreadSortByUpdatedAt ThreadSortBy = "updated_at" // The last time the thread was updated
 )
+
+// The field to sort by.
+type CronSortBy string
+
+const (
+	CronSortByCronID      CronSortBy = "cron_id"       // The cron ID
+	CronSortByAssistantID CronSortBy = "assistant_id"  // The assistant ID
+	CronSortByThreadID    CronSortBy = "thread_id"     // The thread ID
+	CronSortByNextRunDate CronSortBy = "next_run_date" // The next time the cron will run
+	CronSortByEndTime     CronSortBy = "end_time"      // The end date of the cron
+	CronSortByCreatedAt   CronSortBy = "created_at"    // The time the cron was created
+	CronSortByUpdatedAt   CronSortBy = "updated_at"    // The last time the cron was updated
+)
+
+// The cron fields to return from a search.
+type CronSelectField string
+
+const (
+	CronSelectFieldCronID         CronSelectField = "cron_id"          // The cron ID
+	CronSelectFieldAssistantID    CronSelectField = "assistant_id"     // The assistant ID
+	CronSelectFieldThreadID       CronSelectField = "thread_id"        // The thread ID
+	CronSelectFieldUserID         CronSelectField = "user_id"          // The owner of the cron
+	CronSelectFieldSchedule       CronSelectField = "schedule"         // The cron schedule
+	CronSelectFieldEndTime        CronSelectField = "end_time"         // The end date of the cron
+	CronSelectFieldNextRunDate    CronSelectField = "next_run_date"    // The next time the cron will run
+	CronSelectFieldPayload        CronSelectField = "payload"          // The run payload
+	CronSelectFieldMetadata       CronSelectField = "metadata"         // The cron metadata
+	CronSelectFieldEnabled        CronSelectField = "enabled"          // Whether the cron is active
+	CronSelectFieldOnRunCompleted CronSelectField = "on_run_completed" // The thread cleanup behaviour
+	CronSelectFieldCreatedAt      CronSelectField = "created_at"       // The time the cron was created
+	CronSelectFieldUpdatedAt      CronSelectField = "updated_at"       // The last time the cron was updated
+)
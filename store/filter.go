@@ -0,0 +1,401 @@
+// Package store builds filters for searching LangGraph Store items and
+// evaluates them against item values in-process.
+//
+//	filter := store.Eq("status", "active").And(store.Gt("score", 0.5))
+//	m, err := filter.Compile()
+//	resp, err := client.Store.SearchItems(ctx, namespace, &m, nil, nil, nil, nil, nil)
+//
+// The store search endpoint supports $eq, $ne, $gt, $gte, $lt and $lte. The
+// $in and $exists operators are evaluator-only: Match and ParseFilter support
+// them, but Compile rejects filters that use them.
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Operator is a comparison operator of a filter condition
+type Operator string
+
+const (
+	OpEq     Operator = "$eq"     // Equal to the value
+	OpNe     Operator = "$ne"     // Not equal to the value
+	OpGt     Operator = "$gt"     // Greater than the value
+	OpGte    Operator = "$gte"    // Greater than or equal to the value
+	OpLt     Operator = "$lt"     // Less than the value
+	OpLte    Operator = "$lte"    // Less than or equal to the value
+	OpIn     Operator = "$in"     // Equal to one of the values; evaluator-only
+	OpExists Operator = "$exists" // Present (true) or absent (false); evaluator-only
+)
+
+// Condition compares a field of an item value. Nested fields are addressed
+// with dots, e.g. "profile.age".
+type Condition struct {
+	Field string
+	Op    Operator
+	Value any
+}
+
+// Filter is a conjunction of conditions on the fields of an item value. The
+// zero Filter matches every item.
+type Filter struct {
+	conditions []Condition
+}
+
+// Eq matches items whose field equals value.
+func Eq(field string, value any) Filter {
+	return where(field, OpEq, value)
+}
+
+// Ne matches items whose field does not equal value, including items without
+// the field.
+func Ne(field string, value any) Filter {
+	return where(field, OpNe, value)
+}
+
+// Gt matches items whose field is greater than value.
+func Gt(field string, value any) Filter {
+	return where(field, OpGt, value)
+}
+
+// Gte matches items whose field is greater than or equal to value.
+func Gte(field string, value any) Filter {
+	return where(field, OpGte, value)
+}
+
+// Lt matches items whose field is less than value.
+func Lt(field string, value any) Filter {
+	return where(field, OpLt, value)
+}
+
+// Lte matches items whose field is less than or equal to value.
+func Lte(field string, value any) Filter {
+	return where(field, OpLte, value)
+}
+
+// In matches items whose field equals one of values. It is evaluator-only,
+// except with a single value, which Compile turns into an equality.
+func In(field string, values ...any) Filter {
+	return where(field, OpIn, values)
+}
+
+// Exists matches items that have the field. It is evaluator-only.
+func Exists(field string) Filter {
+	return where(field, OpExists, true)
+}
+
+// Missing matches items that do not have the field. It is evaluator-only.
+func Missing(field string) Filter {
+	return where(field, OpExists, false)
+}
+
+func where(field string, op Operator, value any) Filter {
+	return Filter{conditions: []Condition{{Field: field, Op: op, Value: normalize(value)}}}
+}
+
+// And returns a filter matching items that match f and all of others.
+func (f Filter) And(others ...Filter) Filter {
+	conditions := append([]Condition{}, f.conditions...)
+	for _, other := range others {
+		conditions = append(conditions, other.conditions...)
+	}
+	return Filter{conditions: conditions}
+}
+
+// Conditions returns the conditions of the filter.
+func (f Filter) Conditions() []Condition {
+	return append([]Condition{}, f.conditions...)
+}
+
+// IsEmpty reports whether the filter has no conditions.
+func (f Filter) IsEmpty() bool {
+	return len(f.conditions) == 0
+}
+
+// Compile converts the filter to the JSON filter of the store search
+// endpoint, as Map does. It returns an error if the filter uses an
+// evaluator-only operator the server does not support; In with a single
+// value is sent as an equality. Since the JSON form holds one value per field
+// and operator, repeated range bounds are merged into the stricter one, and
+// other repeated conditions with different values are an error.
+func (f Filter) Compile() (map[string]any, error) {
+	compiled := Filter{conditions: make([]Condition, 0, len(f.conditions))}
+	seen := map[string]int{}
+	for _, c := range f.conditions {
+		if values, ok := c.Value.([]any); ok && c.Op == OpIn && len(values) == 1 {
+			c = Condition{Field: c.Field, Op: OpEq, Value: values[0]}
+		}
+		switch c.Op {
+		case OpEq, OpNe, OpGt, OpGte, OpLt, OpLte:
+		default:
+			return nil, fmt.Errorf("filter on '%s': %s is not supported by the store search endpoint", c.Field, c.Op)
+		}
+
+		key := c.Field + "\x00" + string(c.Op)
+		i, ok := seen[key]
+		if !ok {
+			seen[key] = len(compiled.conditions)
+			compiled.conditions = append(compiled.conditions, c)
+			continue
+		}
+		merged, err := stricter(compiled.conditions[i], c)
+		if err != nil {
+			return nil, err
+		}
+		compiled.conditions[i] = merged
+	}
+	return compiled.Map(), nil
+}
+
+// stricter merges two conditions on the same field and operator into one.
+func stricter(a Condition, b Condition) (Condition, error) {
+	if equal(a.Value, b.Value) {
+		return a, nil
+	}
+	cmp, ok := compare(a.Value, b.Value)
+	switch {
+	case !ok:
+	case a.Op == OpGt || a.Op == OpGte:
+		if cmp < 0 {
+			return b, nil
+		}
+		return a, nil
+	case a.Op == OpLt || a.Op == OpLte:
+		if cmp > 0 {
+			return b, nil
+		}
+		return a, nil
+	}
+	return Condition{}, fmt.Errorf("filter on '%s': %s is given twice with different values (%v and %v)", a.Field, a.Op, a.Value, b.Value)
+}
+
+// Map returns the JSON form of the filter, including evaluator-only
+// operators; use Compile for filters sent to the server. A field with a
+// single equality condition is written as a plain value, the form every
+// server version accepts; other conditions become operator maps. The JSON
+// form holds one value per field and operator, so if a field has several
+// conditions with the same operator only the last one is kept.
+func (f Filter) Map() map[string]any {
+	grouped := map[string][]Condition{}
+	for _, c := range f.conditions {
+		grouped[c.Field] = append(grouped[c.Field], c)
+	}
+
+	out := make(map[string]any, len(grouped))
+	for field, conditions := range grouped {
+		if len(conditions) == 1 && conditions[0].Op == OpEq {
+			out[field] = conditions[0].Value
+			continue
+		}
+		ops := map[string]any{}
+		for _, c := range conditions {
+			ops[string(c.Op)] = c.Value
+		}
+		out[field] = ops
+	}
+	return out
+}
+
+// MarshalJSON encodes the filter as returned by Map.
+func (f Filter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Map())
+}
+
+// String returns the filter in its JSON form.
+func (f Filter) String() string {
+	data, err := f.MarshalJSON()
+	if err != nil {
+		return fmt.Sprintf("invalid filter: %v", err)
+	}
+	return string(data)
+}
+
+// ParseFilter parses a JSON filter as accepted by the store search endpoint,
+// so that raw filters can be evaluated with Match.
+func ParseFilter(m map[string]any) (Filter, error) {
+	fields := make([]string, 0, len(m))
+	for field := range m {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var f Filter
+	for _, field := range fields {
+		value := m[field]
+		ops, ok := value.(map[string]any)
+		if !ok || !isOperatorMap(ops) {
+			f = f.And(Eq(field, value))
+			continue
+		}
+
+		names := make([]string, 0, len(ops))
+		for name := range ops {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			op := Operator(name)
+			switch op {
+			case OpEq, OpNe, OpGt, OpGte, OpLt, OpLte:
+			case OpIn:
+				if _, ok := normalize(ops[name]).([]any); !ok {
+					return Filter{}, fmt.Errorf("filter on '%s': %s requires a list", field, name)
+				}
+			case OpExists:
+				if _, ok := ops[name].(bool); !ok {
+					return Filter{}, fmt.Errorf("filter on '%s': %s requires a boolean", field, name)
+				}
+			default:
+				return Filter{}, fmt.Errorf("filter on '%s': unknown operator '%s'", field, name)
+			}
+			f = f.And(where(field, op, ops[name]))
+		}
+	}
+
+	return f, nil
+}
+
+// isOperatorMap reports whether a filter value is a map of operators rather
+// than a nested object to compare for equality.
+func isOperatorMap(m map[string]any) bool {
+	if len(m) == 0 {
+		return false
+	}
+	for key := range m {
+		if !strings.HasPrefix(key, "$") {
+			return false
+		}
+	}
+	return true
+}
+
+// Match reports whether an item value satisfies every condition.
+func (f Filter) Match(value map[string]any) bool {
+	for _, c := range f.conditions {
+		if !c.Match(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Match reports whether an item value satisfies the condition.
+func (c Condition) Match(value map[string]any) bool {
+	actual, ok := lookup(value, c.Field)
+
+	switch c.Op {
+	case OpExists:
+		want, _ := c.Value.(bool)
+		return ok == want
+	case OpNe:
+		return !ok || !equal(actual, c.Value)
+	}
+
+	if !ok {
+		return false
+	}
+
+	switch c.Op {
+	case OpEq:
+		return equal(actual, c.Value)
+	case OpIn:
+		values, _ := c.Value.([]any)
+		for _, v := range values {
+			if equal(actual, v) {
+				return true
+			}
+		}
+		return false
+	case OpGt, OpGte, OpLt, OpLte:
+		cmp, ok := compare(actual, c.Value)
+		if !ok {
+			return false
+		}
+		switch c.Op {
+		case OpGt:
+			return cmp > 0
+		case OpGte:
+			return cmp >= 0
+		case OpLt:
+			return cmp < 0
+		default:
+			return cmp <= 0
+		}
+	}
+
+	return false
+}
+
+func lookup(value map[string]any, field string) (any, bool) {
+	if v, ok := value[field]; ok {
+		return normalize(v), true
+	}
+
+	var current any = value
+	for _, part := range strings.Split(field, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current, ok = m[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return normalize(current), true
+}
+
+func equal(a any, b any) bool {
+	if cmp, ok := compare(a, b); ok {
+		return cmp == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compare orders two numbers or two strings.
+func compare(a any, b any) (int, bool) {
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(x, y), true
+	}
+	return 0, false
+}
+
+// normalize converts a value to its JSON decoded form, so that e.g. an int
+// filter value compares equal to the float64 decoded from an item.
+func normalize(value any) any {
+	switch value.(type) {
+	case nil, bool, float64, string:
+		return value
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
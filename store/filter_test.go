@@ -0,0 +1,107 @@
+package store
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterMap(t *testing.T) {
+	filter := Eq("status", "active").And(Gt("score", 0.5), Lte("score", 1), In("tier", "gold", "silver"), Exists("owner"))
+
+	assert.Equal(t, map[string]any{
+		"status": "active",
+		"score":  map[string]any{"$gt": 0.5, "$lte": float64(1)},
+		"tier":   map[string]any{"$in": []any{"gold", "silver"}},
+		"owner":  map[string]any{"$exists": true},
+	}, filter.Map())
+
+	data, err := json.Marshal(Ne("status", "archived"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status":{"$ne":"archived"}}`, string(data))
+}
+
+func TestFilterCompile(t *testing.T) {
+	compiled, err := Eq("status", "active").And(Gt("score", 0.5), In("tier", "gold")).Compile()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"status": "active",
+		"score":  map[string]any{"$gt": 0.5},
+		"tier":   "gold",
+	}, compiled)
+
+	for _, filter := range []Filter{In("tier", "gold", "silver"), In("tier"), Exists("owner"), Missing("owner")} {
+		_, err := filter.Compile()
+		assert.ErrorContains(t, err, "not supported by the store search endpoint", filter.String())
+	}
+
+	// Repeated range bounds keep the stricter one.
+	compiled, err = Gt("score", 0.5).And(Gt("score", 0.7), Gt("score", 0.6), Lte("score", 3), Lte("score", 2), Gte("name", "b"), Gte("name", "a")).Compile()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"score": map[string]any{"$gt": 0.7, "$lte": float64(2)},
+		"name":  map[string]any{"$gte": "b"},
+	}, compiled)
+
+	compiled, err = Eq("status", "active").And(In("status", "active")).Compile()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"status": "active"}, compiled)
+
+	for _, filter := range []Filter{
+		Eq("status", "active").And(Eq("status", "archived")),
+		Ne("status", "active").And(Ne("status", "archived")),
+		Gt("score", 0.5).And(Gt("score", "high")),
+	} {
+		_, err := filter.Compile()
+		assert.ErrorContains(t, err, "is given twice with different values", filter.String())
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	value := map[string]any{
+		"status":  "active",
+		"score":   0.8,
+		"tier":    "gold",
+		"profile": map[string]any{"age": float64(42)},
+		"tags":    []any{"a", "b"},
+	}
+
+	tests := []struct {
+		filter Filter
+		want   bool
+	}{
+		{Filter{}, true},
+		{Eq("status", "active").And(Gt("score", 0.5)), true},
+		{Eq("status", "active").And(Gt("score", 0.9)), false},
+		{Gte("profile.age", 42), true},
+		{Lt("profile.age", 18), false},
+		{In("tier", "gold", "silver"), true},
+		{In("tier"), false},
+		{Ne("status", "archived"), true},
+		{Ne("missing", "x"), true},
+		{Eq("missing", "x"), false},
+		{Exists("profile.age"), true},
+		{Missing("owner"), true},
+		{Eq("tags", []string{"a", "b"}), true},
+		{Gt("status", 1), false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.filter.Match(value), tt.filter.String())
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	filter, err := ParseFilter(map[string]any{
+		"status":  "active",
+		"score":   map[string]any{"$gte": 0.5},
+		"profile": map[string]any{"age": 42},
+	})
+	assert.NoError(t, err)
+	assert.True(t, filter.Match(map[string]any{"status": "active", "score": 0.5, "profile": map[string]any{"age": 42.0}}))
+	assert.False(t, filter.Match(map[string]any{"status": "active", "score": 0.4, "profile": map[string]any{"age": 42.0}}))
+
+	_, err = ParseFilter(map[string]any{"score": map[string]any{"$regex": "x"}})
+	assert.Error(t, err)
+}